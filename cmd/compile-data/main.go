@@ -0,0 +1,274 @@
+// Command compile-data compiles browsers.yaml into Go tables so the
+// generator package needs no YAML parsing at runtime. It is run by
+// go generate in the generator directory:
+//
+//	go generate ./generator
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+
+	useragent "github.com/r1x0s/go-useragent-utils/generator"
+	_ "github.com/r1x0s/go-useragent-utils/generator/yamldata"
+)
+
+func main() {
+	in := flag.String("in", "browsers.yaml", "data file to compile")
+	out := flag.String("out", "data_gen.go", "Go file to write")
+	flag.Parse()
+
+	fmt.Printf("Compiling %s...\n", *in)
+	config, err := useragent.File(*in).Load()
+	if err != nil {
+		panic(err)
+	}
+	if err := useragent.ValidateData(config); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", *in, err)
+		os.Exit(1)
+	}
+
+	src, err := compile(config, *in)
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		panic(err)
+	}
+	fmt.Printf("Wrote %s.\n", *out)
+}
+
+// compile returns the gofmt'd source of the generated file.
+func compile(config *useragent.Config, name string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by compile-data from %s; DO NOT EDIT.\n\n", name)
+	b.WriteString("package useragent\n\n")
+	b.WriteString("// embeddedConfig returns the embedded data. Every call returns a new\n")
+	b.WriteString("// Config; the version tables are shared and must not be modified.\n")
+	b.WriteString("func embeddedConfig() *Config {\n")
+	b.WriteString("return &Config{\n")
+	if config.SchemaVersion != 0 {
+		fmt.Fprintf(&b, "SchemaVersion: %d,\n", config.SchemaVersion)
+	}
+
+	type table struct {
+		key, name string
+		versions  []useragent.Version
+	}
+	var tables []table
+	needBool := false
+
+	b.WriteString("Browsers: map[string]map[string]PlatformConfig{\n")
+	for _, browser := range sortedKeys(config.Browsers) {
+		fmt.Fprintf(&b, "%q: {\n", browser)
+		platforms := config.Browsers[browser]
+		for _, osName := range sortedKeys(platforms) {
+			p := platforms[osName]
+			versions, err := p.AllVersions()
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", browser, osName, err)
+			}
+
+			fmt.Fprintf(&b, "%q: {\n", osName)
+			fmt.Fprintf(&b, "UATemplate: %q,\n", p.UATemplate)
+			if p.Device != "" {
+				fmt.Fprintf(&b, "Device: %q,\n", p.Device)
+			}
+			if p.VersionsFrom != "" {
+				fmt.Fprintf(&b, "VersionsFrom: %q,\n", p.VersionsFrom)
+			}
+			writeVariants(&b, p.Variants)
+			writeMetadata(&b, buildMetadata(p))
+			if writeOverrides(&b, p.Overrides) {
+				needBool = true
+			}
+			b.WriteString("},\n")
+
+			if len(versions) > 0 {
+				tables = append(tables, table{
+					key:      browser + "/" + osName,
+					name:     "versions" + exportName(browser) + exportName(osName),
+					versions: versions,
+				})
+			}
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("},\n")
+
+	if len(config.MarketShare) > 0 {
+		b.WriteString("MarketShare: []MarketShare{\n")
+		for _, m := range config.MarketShare {
+			fmt.Fprintf(&b, "{Browser: %q, OS: %q, Device: %q, Share: %v},\n", m.Browser, m.OS, m.Device, m.Share)
+		}
+		b.WriteString("},\n")
+	}
+
+	if len(config.Denylist) > 0 {
+		b.WriteString("Denylist: map[string][]DeniedBuild{\n")
+		for _, browser := range sortedKeys(config.Denylist) {
+			fmt.Fprintf(&b, "%q: {\n", browser)
+			for _, d := range config.Denylist[browser] {
+				fmt.Fprintf(&b, "{Version: %q, OS: %q, Reason: %q},\n", d.Version, d.OS, d.Reason)
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+	}
+
+	b.WriteString("compiled: map[string][]Version{\n")
+	for _, t := range tables {
+		fmt.Fprintf(&b, "%q: %s,\n", t.key, t.name)
+	}
+	b.WriteString("},\n")
+	b.WriteString("}\n}\n")
+
+	for _, t := range tables {
+		fmt.Fprintf(&b, "\nvar %s = []Version{\n", t.name)
+		for _, v := range t.versions {
+			parts := make([]string, len(v.Components))
+			for i, c := range v.Components {
+				parts[i] = fmt.Sprint(c)
+			}
+			fmt.Fprintf(&b, "{Components: []int{%s}},\n", strings.Join(parts, ", "))
+		}
+		b.WriteString("}\n")
+	}
+
+	if needBool {
+		b.WriteString("\nfunc newBool(b bool) *bool { return &b }\n")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+func writeVariants(b *bytes.Buffer, variants []useragent.PlatformVariant) {
+	if len(variants) == 0 {
+		return
+	}
+	b.WriteString("Variants: []PlatformVariant{\n")
+	for _, v := range variants {
+		var fields []string
+		add := func(name, value string) {
+			if value != "" {
+				fields = append(fields, fmt.Sprintf("%s: %q", name, value))
+			}
+		}
+		add("OSVersion", v.OSVersion)
+		add("PlatformVersion", v.PlatformVersion)
+		add("Architecture", v.Architecture)
+		add("Bitness", v.Bitness)
+		add("Model", v.Model)
+		add("FormFactor", v.FormFactor)
+		if v.Weight != 0 {
+			fields = append(fields, fmt.Sprintf("Weight: %v", v.Weight))
+		}
+		fmt.Fprintf(b, "{%s},\n", strings.Join(fields, ", "))
+	}
+	b.WriteString("},\n")
+}
+
+func writeMetadata(b *bytes.Buffer, metadata map[string]useragent.VersionMeta) {
+	if len(metadata) == 0 {
+		return
+	}
+	b.WriteString("Metadata: map[string]VersionMeta{\n")
+	for _, key := range sortedKeys(metadata) {
+		m := metadata[key]
+		var fields []string
+		if m.Released != "" {
+			fields = append(fields, fmt.Sprintf("Released: %q", m.Released))
+		}
+		if m.EOL != "" {
+			fields = append(fields, fmt.Sprintf("EOL: %q", m.EOL))
+		}
+		if m.Channel != "" {
+			fields = append(fields, fmt.Sprintf("Channel: %q", m.Channel))
+		}
+		if m.Engine != "" {
+			fields = append(fields, fmt.Sprintf("Engine: %q", m.Engine))
+		}
+		fmt.Fprintf(b, "%q: {%s},\n", key, strings.Join(fields, ", "))
+	}
+	b.WriteString("},\n")
+}
+
+// writeOverrides writes the overrides and reports whether they use newBool.
+func writeOverrides(b *bytes.Buffer, overrides []useragent.Override) bool {
+	if len(overrides) == 0 {
+		return false
+	}
+	usesBool := false
+	b.WriteString("Overrides: []Override{\n")
+	for _, o := range overrides {
+		fmt.Fprintf(b, "{\nVersions: %q,\n", o.Versions)
+		if o.UATemplate != "" {
+			fmt.Fprintf(b, "UATemplate: %q,\n", o.UATemplate)
+		}
+		if o.ClientHints != nil {
+			fmt.Fprintf(b, "ClientHints: newBool(%t),\n", *o.ClientHints)
+			usesBool = true
+		}
+		if len(o.Headers) > 0 {
+			b.WriteString("Headers: map[string]string{\n")
+			for _, name := range sortedKeys(o.Headers) {
+				fmt.Fprintf(b, "%q: %q,\n", name, o.Headers[name])
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("},\n")
+	return usesBool
+}
+
+// buildMetadata merges the metadata of flat builds into the metadata map,
+// the same way the generator does when loading the data.
+func buildMetadata(p useragent.PlatformConfig) map[string]useragent.VersionMeta {
+	if len(p.Builds) == 0 {
+		return p.Metadata
+	}
+	metadata := make(map[string]useragent.VersionMeta, len(p.Metadata)+len(p.Builds))
+	for key, meta := range p.Metadata {
+		metadata[key] = meta
+	}
+	for _, build := range p.Builds {
+		if build.VersionMeta != (useragent.VersionMeta{}) {
+			v, _ := useragent.ParseVersion(build.Version) // Validated by AllVersions
+			metadata[v.String()] = build.VersionMeta
+		}
+	}
+	return metadata
+}
+
+// exportName turns a data key like "macos" into "Macos" for variable names.
+func exportName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9') {
+			upper = true
+			continue
+		}
+		if upper && 'a' <= r && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
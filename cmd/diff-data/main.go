@@ -0,0 +1,138 @@
+// Command diff-data compares two data files in the format of browsers.yaml
+// and reports added, removed and changed versions, templates and metadata
+// per browser/OS pair:
+//
+//	go run ./cmd/diff-data [-json] OLD [NEW]
+//
+// With a single file, the embedded data is compared against it. Like diff,
+// it exits with status 1 if the datasets differ.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	useragent "github.com/r1x0s/go-useragent-utils/generator"
+	_ "github.com/r1x0s/go-useragent-utils/generator/yamldata"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "print the differences as JSON")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: diff-data [-json] OLD [NEW]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var oldSrc, newSrc useragent.DataSource
+	switch flag.NArg() {
+	case 1:
+		oldSrc, newSrc = useragent.Embedded(), useragent.File(flag.Arg(0))
+	case 2:
+		oldSrc, newSrc = useragent.File(flag.Arg(0)), useragent.File(flag.Arg(1))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	oldConfig, err := oldSrc.Load()
+	if err != nil {
+		fail(err)
+	}
+	newConfig, err := newSrc.Load()
+	if err != nil {
+		fail(err)
+	}
+	diff, err := useragent.DiffData(oldConfig, newConfig)
+	if err != nil {
+		fail(err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diff); err != nil {
+			fail(err)
+		}
+	} else {
+		printDiff(diff)
+	}
+	if !diff.Empty() {
+		os.Exit(1)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
+
+func printDiff(d *useragent.DataDiff) {
+	if d.Empty() {
+		fmt.Println("No differences.")
+		return
+	}
+	for _, p := range d.Platforms {
+		fmt.Printf("%s/%s: %s\n", p.Browser, p.OS, p.Status)
+		printVersions("+", p.AddedVersions)
+		printVersions("-", p.RemovedVersions)
+		printChange("template", p.Template)
+		printChange("device", p.Device)
+		printChange("versions_from", p.VersionsFrom)
+		for _, m := range p.Metadata {
+			fmt.Printf("  metadata %s %s: %s\n", m.Key, m.Field, change(m.Old, m.New))
+		}
+		if p.VariantsChanged {
+			fmt.Println("  variants changed")
+		}
+		if p.OverridesChanged {
+			fmt.Println("  overrides changed")
+		}
+	}
+	for _, e := range d.DenylistAdded {
+		fmt.Printf("denylist %s: + %s\n", e.Browser, denied(e.DeniedBuild))
+	}
+	for _, e := range d.DenylistRemoved {
+		fmt.Printf("denylist %s: - %s\n", e.Browser, denied(e.DeniedBuild))
+	}
+	if d.MarketShareChanged {
+		fmt.Println("market_share: changed")
+	}
+}
+
+// printVersions prints versions one major per line, e.g.
+// "  + 142: 142.0.7444.60, 142.0.7444.61".
+func printVersions(sign string, versions []useragent.Version) {
+	for i := 0; i < len(versions); {
+		major := versions[i].Components[0]
+		var group []string
+		for ; i < len(versions) && versions[i].Components[0] == major; i++ {
+			group = append(group, versions[i].String())
+		}
+		fmt.Printf("  %s %d: %s\n", sign, major, strings.Join(group, ", "))
+	}
+}
+
+func printChange(name string, c *useragent.Change) {
+	if c != nil {
+		fmt.Printf("  %s: %s\n", name, change(c.Old, c.New))
+	}
+}
+
+func change(old, new string) string {
+	return fmt.Sprintf("%q -> %q", old, new)
+}
+
+func denied(b useragent.DeniedBuild) string {
+	s := b.Version
+	if b.OS != "" {
+		s += " on " + string(b.OS)
+	}
+	if b.Reason != "" {
+		s += " (" + b.Reason + ")"
+	}
+	return s
+}
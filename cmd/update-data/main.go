@@ -10,14 +10,21 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	chromeVersionsURL = "https://googlechromelabs.github.io/chrome-for-testing/known-good-versions.json"
+	chromeReleasesURL = "https://chromiumdash.appspot.com/fetch_releases?channel=Stable&platform=Windows&num=1000"
 	minMajorVersion   = 133
-	dataFile          = "data/browsers.yaml"
+	dataFile          = "generator/browsers.yaml"
+	dateLayout        = "2006-01-02"
+
+	// supportedSchemaVersion is the data schema this tool writes; it matches
+	// the generator package's CurrentSchemaVersion.
+	supportedSchemaVersion = 1
 )
 
 type ChromeVersionsResponse struct {
@@ -26,14 +33,75 @@ type ChromeVersionsResponse struct {
 	} `json:"versions"`
 }
 
+// ChromeRelease is an entry of the Chromium Dash releases API.
+type ChromeRelease struct {
+	Version   string  `json:"version"`
+	Milestone int     `json:"milestone"`
+	Time      float64 `json:"time"` // Unix milliseconds
+}
+
 // Config matches the structure in pkg/useragent/types.go but simplified for manipulation
 type Config struct {
-	Browsers map[string]map[string]PlatformConfig `yaml:"browsers"`
+	SchemaVersion int                                  `yaml:"schema_version,omitempty"`
+	Browsers      map[string]map[string]PlatformConfig `yaml:"browsers"`
+	MarketShare   []MarketShare                        `yaml:"market_share,omitempty"`
+	Denylist      map[string][]DeniedBuild             `yaml:"denylist,omitempty"`
 }
 
 type PlatformConfig struct {
-	UATemplate string              `yaml:"ua_template"`
-	Versions   map[int]interface{} `yaml:"versions"`
+	UATemplate   string                 `yaml:"ua_template"`
+	Device       string                 `yaml:"device,omitempty"`
+	VersionsFrom string                 `yaml:"versions_from,omitempty"`
+	Variants     []PlatformVariant      `yaml:"variants,omitempty"`
+	Versions     map[int]interface{}    `yaml:"versions,omitempty"`
+	Builds       []Build                `yaml:"builds,omitempty"`
+	Metadata     map[string]VersionMeta `yaml:"metadata,omitempty"`
+	Overrides    []Override             `yaml:"overrides,omitempty"`
+}
+
+// Override is kept as is; it is curated by hand.
+type Override struct {
+	Versions    string            `yaml:"versions"`
+	UATemplate  string            `yaml:"ua_template,omitempty"`
+	ClientHints *bool             `yaml:"client_hints,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+}
+
+type Build struct {
+	Version     string `yaml:"version"`
+	VersionMeta `yaml:",inline"`
+}
+
+type MarketShare struct {
+	Browser string  `yaml:"browser"`
+	OS      string  `yaml:"os"`
+	Device  string  `yaml:"device,omitempty"`
+	Share   float64 `yaml:"share"`
+}
+
+// DeniedBuild is a curated denylist entry; it is kept as is and denied
+// builds are not added to the version tree.
+type DeniedBuild struct {
+	Version string `yaml:"version"`
+	OS      string `yaml:"os,omitempty"`
+	Reason  string `yaml:"reason,omitempty"`
+}
+
+type PlatformVariant struct {
+	OSVersion       string  `yaml:"os_version,omitempty"`
+	PlatformVersion string  `yaml:"platform_version,omitempty"`
+	Architecture    string  `yaml:"architecture,omitempty"`
+	Bitness         string  `yaml:"bitness,omitempty"`
+	Model           string  `yaml:"model,omitempty"`
+	FormFactor      string  `yaml:"form_factor,omitempty"`
+	Weight          float64 `yaml:"weight,omitempty"`
+}
+
+type VersionMeta struct {
+	Released string `yaml:"released,omitempty"`
+	EOL      string `yaml:"eol,omitempty"`
+	Channel  string `yaml:"channel,omitempty"`
+	Engine   string `yaml:"engine,omitempty"`
 }
 
 func main() {
@@ -47,11 +115,18 @@ func main() {
 	filtered := filterVersions(versions)
 	fmt.Printf("Kept %d versions.\n", len(filtered))
 
-	fmt.Println("Updating data/browsers.yaml...")
-	if err := updateYAML(filtered); err != nil {
+	fmt.Println("Fetching Chrome stable release dates...")
+	releases, err := fetchChromeReleases()
+	if err != nil {
 		panic(err)
 	}
-	fmt.Println("Done!")
+	fmt.Printf("Found %d stable releases.\n", len(releases))
+
+	fmt.Printf("Updating %s...\n", dataFile)
+	if err := updateYAML(filtered, releases); err != nil {
+		panic(err)
+	}
+	fmt.Println("Done! Run go generate ./generator to compile the new data.")
 }
 
 func fetchChromeVersions() ([]string, error) {
@@ -78,6 +153,53 @@ func fetchChromeVersions() ([]string, error) {
 	return versions, nil
 }
 
+func fetchChromeReleases() ([]ChromeRelease, error) {
+	resp, err := http.Get(chromeReleasesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []ChromeRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// releaseMetadata computes release and end-of-life dates. Every stable build
+// gets its own release date; a major is released with its first stable build
+// and reaches end of life when the next major ships.
+func releaseMetadata(releases []ChromeRelease) map[string]VersionMeta {
+	meta := make(map[string]VersionMeta)
+	majorReleased := make(map[int]time.Time)
+
+	for _, r := range releases {
+		if r.Milestone < minMajorVersion {
+			continue
+		}
+		released := time.UnixMilli(int64(r.Time)).UTC()
+		meta[r.Version] = VersionMeta{Released: released.Format(dateLayout)}
+		if first, ok := majorReleased[r.Milestone]; !ok || released.Before(first) {
+			majorReleased[r.Milestone] = released
+		}
+	}
+
+	for major, released := range majorReleased {
+		m := VersionMeta{Released: released.Format(dateLayout)}
+		if next, ok := majorReleased[major+1]; ok {
+			m.EOL = next.Format(dateLayout)
+		}
+		meta[strconv.Itoa(major)] = m
+	}
+	return meta
+}
+
 func filterVersions(versions []string) []string {
 	var res []string
 	for _, v := range versions {
@@ -92,7 +214,7 @@ func filterVersions(versions []string) []string {
 	return res
 }
 
-func updateYAML(newVersions []string) error {
+func updateYAML(newVersions []string, releases []ChromeRelease) error {
 	// Read existing file
 	path, _ := filepath.Abs(dataFile)
 	content, err := os.ReadFile(path)
@@ -104,6 +226,9 @@ func updateYAML(newVersions []string) error {
 	if err := yaml.Unmarshal(content, &config); err != nil {
 		return err
 	}
+	if config.SchemaVersion > supportedSchemaVersion {
+		return fmt.Errorf("%s has schema version %d, this tool supports %d", dataFile, config.SchemaVersion, supportedSchemaVersion)
+	}
 
 	// Initialize if empty
 	if config.Browsers == nil {
@@ -113,7 +238,7 @@ func updateYAML(newVersions []string) error {
 		config.Browsers["chrome"] = make(map[string]PlatformConfig)
 	}
 
-	// Update Windows versions
+	// Update Windows versions; platforms with versions_from follow automatically
 	winConfig, ok := config.Browsers["chrome"]["windows"]
 	if !ok {
 		// Should exist based on our seed, but handle anyway
@@ -124,13 +249,34 @@ func updateYAML(newVersions []string) error {
 	}
 
 	// Merge new versions into the map
+	skipped := 0
 	for _, vStr := range newVersions {
 		parts := parseVersion(vStr)
 		if len(parts) == 0 {
 			continue
 		}
+		if denied(config.Denylist["chrome"], "windows", parts) {
+			skipped++
+			continue
+		}
 		addToMap(winConfig.Versions, parts)
 	}
+	if skipped > 0 {
+		fmt.Printf("Skipped %d denylisted versions.\n", skipped)
+	}
+
+	// Merge release dates, keeping manually curated entries that the API does not know
+	if winConfig.Metadata == nil {
+		winConfig.Metadata = make(map[string]VersionMeta)
+	}
+	for key, m := range releaseMetadata(releases) {
+		existing := winConfig.Metadata[key]
+		existing.Released = m.Released
+		if m.EOL != "" {
+			existing.EOL = m.EOL
+		}
+		winConfig.Metadata[key] = existing
+	}
 
 	config.Browsers["chrome"]["windows"] = winConfig
 
@@ -153,6 +299,30 @@ func parseVersion(v string) []int {
 	return res
 }
 
+// denied reports whether a version matches a denylist entry for the OS.
+func denied(entries []DeniedBuild, osName string, version []int) bool {
+	for _, e := range entries {
+		if e.OS != "" && e.OS != osName {
+			continue
+		}
+		prefix := parseVersion(e.Version)
+		if len(prefix) > len(version) {
+			continue
+		}
+		match := true
+		for i, c := range prefix {
+			if version[i] != c {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
 // addToMap recursively adds the version components to the nested map
 func addToMap(m map[int]interface{}, components []int) {
 	if len(components) == 0 {
@@ -0,0 +1,59 @@
+// Command validate-data strictly checks data files in the format of
+// browsers.yaml and prints every problem with its line and column:
+//
+//	go run ./cmd/validate-data [file ...]
+//
+// Without arguments it checks generator/browsers.yaml. It exits with
+// status 1 if any file has problems.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	useragent "github.com/r1x0s/go-useragent-utils/generator"
+	_ "github.com/r1x0s/go-useragent-utils/generator/yamldata"
+)
+
+const dataFile = "generator/browsers.yaml"
+
+func main() {
+	files := os.Args[1:]
+	if len(files) == 0 {
+		files = []string{dataFile}
+	}
+
+	failed := false
+	for _, file := range files {
+		if !validate(file) {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// validate reports the problems of one file and whether it is valid.
+func validate(file string) bool {
+	config, err := useragent.File(file).Load()
+	if err == nil {
+		err = useragent.ValidateData(config)
+	}
+
+	var problems useragent.DataErrors
+	switch {
+	case err == nil:
+		fmt.Printf("%s: ok\n", file)
+		return true
+	case errors.As(err, &problems):
+		for _, p := range problems {
+			fmt.Printf("%s:%s\n", file, p)
+		}
+		fmt.Printf("%s: %d problems\n", file, len(problems))
+	default:
+		fmt.Printf("%s: %v\n", file, err)
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package useragent
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AutomationProfile selects a headless or automation-driven Chrome flavour.
+// These profiles reproduce the tokens and header quirks that bot detection
+// looks for; they are meant for testing detection and are never chosen
+// unless requested with WithAutomationProfile.
+type AutomationProfile string
+
+const (
+	// ProfileRealistic is a regular, user-driven browser (default).
+	ProfileRealistic AutomationProfile = ""
+	// ProfileHeadlessOld is the legacy headless mode (--headless=old, chrome-headless-shell):
+	// "HeadlessChrome" UA token and brand, and no Accept-Language header.
+	ProfileHeadlessOld AutomationProfile = "headless-old"
+	// ProfileHeadlessNew is the new headless mode (--headless=new):
+	// "HeadlessChrome" UA token and brand, otherwise regular headers.
+	ProfileHeadlessNew AutomationProfile = "headless-new"
+	// ProfileChromeForTesting is the unbranded Chrome for Testing build:
+	// regular UA, but no "Google Chrome" brand.
+	ProfileChromeForTesting AutomationProfile = "chrome-for-testing"
+	// ProfileWebDriver is regular Chrome driven by chromedriver. Headers are
+	// unchanged; the tell is navigator.webdriver, reported by Webdriver.
+	ProfileWebDriver AutomationProfile = "webdriver"
+	// ProfilePuppeteer is Puppeteer's default launch: Chrome for Testing in
+	// new headless mode.
+	ProfilePuppeteer AutomationProfile = "puppeteer"
+)
+
+// automationQuirks describes how a profile deviates from a realistic browser.
+type automationQuirks struct {
+	headlessToken      bool // "HeadlessChrome/" replaces "Chrome/" in the UA and brands
+	unbranded          bool // no vendor brand, as in Chrome for Testing
+	noAcceptLanguage   bool
+	navigatorWebdriver bool
+}
+
+var automationProfiles = map[AutomationProfile]automationQuirks{
+	ProfileRealistic:        {},
+	ProfileHeadlessOld:      {headlessToken: true, noAcceptLanguage: true, navigatorWebdriver: true},
+	ProfileHeadlessNew:      {headlessToken: true, navigatorWebdriver: true},
+	ProfileChromeForTesting: {unbranded: true},
+	ProfileWebDriver:        {navigatorWebdriver: true},
+	ProfilePuppeteer:        {headlessToken: true, unbranded: true, navigatorWebdriver: true},
+}
+
+// Webdriver reports whether navigator.webdriver is true under this profile.
+func (p AutomationProfile) Webdriver() bool {
+	return automationProfiles[p].navigatorWebdriver
+}
+
+// WithAutomationProfile generates a headless or automation-driven Chrome instead
+// of a realistic one. Only available for Chrome.
+func WithAutomationProfile(p AutomationProfile) Option {
+	return func(o *generateOptions) {
+		o.profile = p
+	}
+}
+
+// checkAutomationProfile verifies that the profile exists and applies to the browser.
+func checkAutomationProfile(p AutomationProfile, browser BrowserName) error {
+	if _, ok := automationProfiles[p]; !ok {
+		return fmt.Errorf("unknown automation profile %q", p)
+	}
+	if p != ProfileRealistic && browser != Chrome {
+		return fmt.Errorf("automation profile %s is only available for %s", p, Chrome)
+	}
+	return nil
+}
+
+// applyAutomationUA rewrites the UA string for the profile.
+func applyAutomationUA(ua string, p AutomationProfile) string {
+	if automationProfiles[p].headlessToken {
+		return strings.Replace(ua, "Chrome/", "HeadlessChrome/", 1)
+	}
+	return ua
+}
+
+// applyAutomationHints rewrites the brand lists for the profile.
+func applyAutomationHints(h *ClientHints, p AutomationProfile) {
+	q := automationProfiles[p]
+	if !q.headlessToken && !q.unbranded {
+		return
+	}
+	// Headless builds report "HeadlessChrome" in place of the product brand,
+	// which takes precedence over dropping it for unbranded builds.
+	rewrite := func(brands []Brand) []Brand {
+		var out []Brand
+		for _, b := range brands {
+			if b.Brand == vendorBrand(Chrome) {
+				if q.unbranded && !q.headlessToken {
+					continue
+				}
+				if q.headlessToken {
+					b.Brand = "HeadlessChrome"
+				}
+			}
+			out = append(out, b)
+		}
+		return out
+	}
+	h.Brands = rewrite(h.Brands)
+	h.FullVersionList = rewrite(h.FullVersionList)
+}
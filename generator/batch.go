@@ -0,0 +1,241 @@
+package useragent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Uniqueness controls which results GenerateN treats as duplicates.
+type Uniqueness int
+
+const (
+	// AllowDuplicates draws every result independently (default).
+	AllowDuplicates Uniqueness = iota
+	// UniqueUserAgents guarantees distinct User-Agent strings.
+	UniqueUserAgents
+	// UniqueIdentities guarantees distinct User-Agent and header combinations,
+	// so two results may share a UA string but differ in e.g. GREASE brand.
+	UniqueIdentities
+)
+
+// ErrInsufficientCandidates is returned by GenerateN when the filtered
+// candidate space holds fewer distinct results than requested.
+var ErrInsufficientCandidates = errors.New("not enough distinct candidates")
+
+// WithUniqueness sets the distinctness guarantee for GenerateN.
+// It has no effect on Generate.
+func WithUniqueness(u Uniqueness) Option {
+	return func(o *generateOptions) {
+		o.uniqueness = u
+	}
+}
+
+// GenerateN creates n results. With WithUniqueness the results are sampled
+// without replacement from the distinct candidates, keeping the selection
+// strategy and market-share weights, and an error wrapping
+// ErrInsufficientCandidates is returned when fewer than n exist.
+func (g *Generator) GenerateN(n int, opts ...Option) ([]*Result, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid count %d", n)
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.uniqueness == AllowDuplicates {
+		results := make([]*Result, 0, n)
+		for i := 0; i < n; i++ {
+			res, err := g.Generate(opts...)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, res)
+		}
+		return results, nil
+	}
+
+	groups, err := g.distinctCandidates(options)
+	if err != nil {
+		return nil, err
+	}
+	if n > len(groups) {
+		return nil, fmt.Errorf("%w: requested %d, only %d available", ErrInsufficientCandidates, n, len(groups))
+	}
+
+	weights := make([]float64, len(groups))
+	for i, grp := range groups {
+		weights[i] = grp.weight
+	}
+
+	// Every group has a positive weight, so zeroing picked ones samples without replacement.
+	results := make([]*Result, 0, n)
+	for len(results) < n {
+		i := g.pickWeighted(weights)
+		weights[i] = 0
+		results = append(results, g.renderGroup(groups[i], options))
+	}
+	return results, nil
+}
+
+// candidateSlot is one way of rendering a result. Slots point into the
+// platform choices so the enumeration stays cheap.
+type candidateSlot struct {
+	choice    *platformChoice
+	candidate *Candidate
+	grease    string // Empty picks one at random when rendered
+	weight    float64
+}
+
+// candidateGroup collects the slots that render to the same distinct result.
+type candidateGroup struct {
+	slots  []candidateSlot
+	weight float64
+}
+
+// distinctCandidates enumerates every candidate and groups them by the
+// uniqueness key. Slot weights combine the platform weight with the
+// selection strategy's weight for the version. Nothing is rendered here;
+// only the groups GenerateN picks are.
+func (g *Generator) distinctCandidates(opts *generateOptions) ([]*candidateGroup, error) {
+	choices, err := g.platformChoices(opts)
+	if err != nil {
+		return nil, err
+	}
+	totalChoice := 0.0
+	for _, c := range choices {
+		totalChoice += c.weight
+	}
+	strategy := opts.strategy
+	if strategy == nil {
+		strategy = Linear()
+	}
+
+	greases := []string{""}
+	if opts.uniqueness == UniqueIdentities {
+		greases = greaseBrands
+	}
+
+	byKey := make(map[string]*candidateGroup)
+	var groups []*candidateGroup
+	for ci := range choices {
+		c := &choices[ci]
+		vw := strategy.Weights(c.versions)
+		if len(vw) != len(c.versions) {
+			return nil, fmt.Errorf("selection strategy returned %d weights for %d versions", len(vw), len(c.versions))
+		}
+		totalVersion := 0.0
+		for _, w := range vw {
+			if w > 0 {
+				totalVersion += w
+			}
+		}
+
+		for i := range c.versions {
+			if vw[i] <= 0 {
+				continue
+			}
+			totalVariant := 0.0
+			for _, cand := range c.candidates[i] {
+				totalVariant += cand.weight
+			}
+			for j := range c.candidates[i] {
+				cand := &c.candidates[i][j]
+				key, greased := uniquenessKey(c.data, c.selection(*cand, opts), opts)
+				for _, grease := range greases {
+					k := key
+					if greased {
+						k = grease + "\x00" + key
+					}
+					grp, ok := byKey[k]
+					if !ok {
+						grp = &candidateGroup{}
+						byKey[k] = grp
+						groups = append(groups, grp)
+					}
+					w := c.weight / totalChoice * vw[i] / totalVersion * cand.weight / totalVariant / float64(len(greases))
+					grp.slots = append(grp.slots, candidateSlot{choice: c, candidate: cand, grease: grease, weight: w})
+					grp.weight += w
+				}
+			}
+		}
+	}
+	return groups, nil
+}
+
+// renderGroup renders one result from a group, picking among its slots by weight.
+func (g *Generator) renderGroup(grp *candidateGroup, opts *generateOptions) *Result {
+	slot := grp.slots[0]
+	if len(grp.slots) > 1 {
+		weights := make([]float64, len(grp.slots))
+		for i, s := range grp.slots {
+			weights[i] = s.weight
+		}
+		if i := g.pickWeighted(weights); i >= 0 {
+			slot = grp.slots[i]
+		}
+	}
+	sel := slot.choice.selection(*slot.candidate, opts)
+	sel.grease = slot.grease
+	return g.render(slot.choice.data, sel, opts)
+}
+
+// uniquenessKey identifies the result sel renders to without rendering it:
+// the UA string and, for UniqueIdentities, the candidate fields behind each
+// requested header. Headers set by overrides are the same for every
+// candidate they apply to and enter the key as-is. greased reports whether
+// the GREASE brand shows in the headers and so belongs in the key too.
+func uniquenessKey(bd *browserData, sel selection, opts *generateOptions) (key string, greased bool) {
+	rules := bd.rules(sel.version)
+	ua := applyAutomationUA(rules.uaTemplate.execute(sel.candidate), sel.profile)
+	if opts.uniqueness != UniqueIdentities {
+		return ua, false
+	}
+
+	var b strings.Builder
+	b.WriteString(ua)
+	write := func(values ...string) {
+		for _, v := range values {
+			b.WriteByte(0)
+			b.WriteString(v)
+		}
+	}
+	names := make([]string, 0, len(rules.headers))
+	for name := range rules.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		write(name, rules.headers[name])
+	}
+	if !rules.clientHints || !supportsClientHints(sel.browser, sel.os) {
+		return b.String(), false
+	}
+
+	c := sel.candidate
+	hint := func(enabled bool, header string, values ...string) bool {
+		if _, overridden := rules.headers[header]; !enabled || overridden {
+			return false
+		}
+		write(header)
+		write(values...)
+		return true
+	}
+	major := ""
+	if len(sel.version.Components) > 0 {
+		major = strconv.Itoa(sel.version.Components[0])
+	}
+	greased = hint(opts.withSecCHUA, "Sec-CH-UA", major)
+	greased = hint(opts.withSecCHUAFullVersion, "Sec-CH-UA-Full-Version-List", sel.version.String()) || greased
+	hint(opts.withSecCHUAMobile, "Sec-CH-UA-Mobile", string(sel.device))
+	hint(opts.withSecCHUAPlatform, "Sec-CH-UA-Platform", string(sel.os))
+	hint(opts.withSecCHUAPlatformVer, "Sec-CH-UA-Platform-Version", c.PlatformVersion)
+	hint(opts.withSecCHUABitness, "Sec-CH-UA-Bitness", c.Bitness)
+	hint(opts.withSecCHUAArch, "Sec-CH-UA-Arch", c.Architecture)
+	hint(opts.withSecCHUAModel, "Sec-CH-UA-Model", c.Model)
+	hint(opts.withSecCHUAFormFactors, "Sec-CH-UA-Form-Factors", c.FormFactor)
+	return b.String(), greased
+}
@@ -0,0 +1,132 @@
+package useragent
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestGenerateN(t *testing.T) {
+	g, err := NewWithSeed(7)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	// Reduced UA strings only differ by major, so UA uniqueness is tested
+	// with templates that render every build.
+	full := fullVersionGenerator(t)
+
+	t.Run("UniqueUserAgents", func(t *testing.T) {
+		results, err := full.GenerateN(200, WithUniqueness(UniqueUserAgents), WithMarketShare())
+		if err != nil {
+			t.Fatalf("GenerateN failed: %v", err)
+		}
+		seen := make(map[string]bool)
+		for _, res := range results {
+			if seen[res.UserAgent] {
+				t.Fatalf("Duplicate UA %s", res.UserAgent)
+			}
+			seen[res.UserAgent] = true
+		}
+	})
+
+	t.Run("ExhaustSmallSpace", func(t *testing.T) {
+		// 133.0.6943.x has only a handful of builds; all of them must come back.
+		opts := []Option{WithMinVersion("133.0.6943"), WithMaxVersion("133.0.6943.999"), WithUniqueness(UniqueUserAgents)}
+		bd := full.data().data[Chrome][Windows]
+		o := defaultOptions()
+		for _, opt := range opts {
+			opt(o)
+		}
+		versions, _, err := full.filterCandidates(bd, o)
+		if err != nil {
+			t.Fatalf("filterCandidates failed: %v", err)
+		}
+		available := len(versions)
+
+		results, err := full.GenerateN(available, opts...)
+		if err != nil {
+			t.Fatalf("GenerateN failed: %v", err)
+		}
+		if len(results) != available {
+			t.Fatalf("Expected %d results, got %d", available, len(results))
+		}
+
+		_, err = full.GenerateN(available+1, opts...)
+		if !errors.Is(err, ErrInsufficientCandidates) {
+			t.Errorf("Expected ErrInsufficientCandidates, got %v", err)
+		}
+	})
+
+	t.Run("KeysMatchRendering", func(t *testing.T) {
+		// Groups are keyed without rendering; rendering every slot must agree.
+		o := defaultOptions()
+		for _, opt := range []Option{WithMinVersion("133.0.6943"), WithMaxVersion("133.0.6943.999"), WithOS(Android), WithAllClientHints(), WithUniqueness(UniqueIdentities)} {
+			opt(o)
+		}
+		groups, err := g.distinctCandidates(o)
+		if err != nil {
+			t.Fatalf("distinctCandidates failed: %v", err)
+		}
+		owner := make(map[string]int)
+		for i, grp := range groups {
+			for _, slot := range grp.slots {
+				sel := slot.choice.selection(*slot.candidate, o)
+				sel.grease = slot.grease
+				if sel.grease == "" {
+					sel.grease = greaseBrands[0]
+				}
+				res := g.render(slot.choice.data, sel, o)
+				key := res.UserAgent
+				for _, name := range hintNames {
+					key += "\x00" + res.Headers[name]
+				}
+				if j, ok := owner[key]; ok && j != i {
+					t.Fatalf("Groups %d and %d render the same identity", j, i)
+				}
+				owner[key] = i
+			}
+		}
+		if len(owner) != len(groups) {
+			t.Errorf("Expected %d distinct identities, rendered %d", len(groups), len(owner))
+		}
+	})
+
+	t.Run("UniqueIdentities", func(t *testing.T) {
+		opts := []Option{WithMinVersion("133.0.6943.53"), WithMaxVersion("133.0.6943.53"), WithClientHints()}
+		if _, err := g.GenerateN(2, append(opts, WithUniqueness(UniqueUserAgents))...); !errors.Is(err, ErrInsufficientCandidates) {
+			t.Fatalf("Expected a single distinct UA, got %v", err)
+		}
+
+		results, err := g.GenerateN(len(greaseBrands), append(opts, WithUniqueness(UniqueIdentities))...)
+		if err != nil {
+			t.Fatalf("GenerateN failed: %v", err)
+		}
+		seen := make(map[string]bool)
+		for _, res := range results {
+			if seen[res.Headers["Sec-CH-UA"]] {
+				t.Fatalf("Duplicate identity %s", res.Headers["Sec-CH-UA"])
+			}
+			seen[res.Headers["Sec-CH-UA"]] = true
+		}
+	})
+}
+
+// fullVersionGenerator returns a seeded generator over the embedded data
+// with templates that render the full version.
+func fullVersionGenerator(t *testing.T) *Generator {
+	t.Helper()
+	config, err := Embedded().Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	for osName, p := range config.Browsers["chrome"] {
+		p.UATemplate = strings.Replace(p.UATemplate, "{{reduced_version}}", "{{version}}", 1)
+		config.Browsers["chrome"][osName] = p
+	}
+	g, err := NewWithSource(FromConfig(config), WithRandSource(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("NewWithSource failed: %v", err)
+	}
+	return g
+}
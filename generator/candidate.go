@@ -0,0 +1,235 @@
+package useragent
+
+import (
+	"fmt"
+	"time"
+)
+
+// ChannelStable is the release channel of versions without channel metadata.
+const ChannelStable = "stable"
+
+// Candidate is a fully specified result that Generate may produce: one
+// version of a browser on one variant of an operating system.
+type Candidate struct {
+	Browser  BrowserName
+	OS       OSName
+	Device   DeviceClass
+	Version  Version
+	Channel  string    // Release channel, "stable" unless the data says otherwise
+	Released time.Time // Release date, zero when unknown
+	Engine   string    // Rendering engine version, empty when unknown
+
+	OSVersion       string // Marketing OS version, e.g. "11"; empty when unknown
+	PlatformVersion string // Sec-CH-UA-Platform-Version value
+	Architecture    string // Sec-CH-UA-Arch value, e.g. "x86" or "arm"
+	Bitness         string
+	Model           string // Device model, empty on desktop
+	FormFactor      string // Sec-CH-UA-Form-Factors value
+
+	weight float64 // Relative weight of the variant
+}
+
+// platformVariant is a PlatformVariant with the platform defaults applied.
+type platformVariant struct {
+	osVersion       string
+	platformVersion string
+	arch            string
+	bitness         string
+	model           string
+	formFactor      string
+	weight          float64
+}
+
+// resolveVariants applies the OS profile defaults to the configured
+// variants. Without configured variants the defaults form the only variant.
+func resolveVariants(os OSName, device DeviceClass, configured []PlatformVariant) ([]platformVariant, error) {
+	if len(configured) == 0 {
+		configured = []PlatformVariant{{}}
+	}
+	profile := platformProfiles[os]
+
+	variants := make([]platformVariant, len(configured))
+	for i, c := range configured {
+		if c.Weight < 0 {
+			return nil, fmt.Errorf("variant %d has a negative weight", i)
+		}
+		v := platformVariant{
+			osVersion:       c.OSVersion,
+			platformVersion: firstNonEmpty(c.PlatformVersion, profile.version),
+			arch:            firstNonEmpty(c.Architecture, profile.arch),
+			bitness:         firstNonEmpty(c.Bitness, profile.bitness),
+			model:           c.Model,
+			formFactor:      firstNonEmpty(c.FormFactor, formFactor(device)),
+			weight:          c.Weight,
+		}
+		if v.weight == 0 {
+			v.weight = 1
+		}
+		variants[i] = v
+	}
+	return variants, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// candidate builds the candidate for a version on a variant.
+func (bd *browserData) candidate(browser BrowserName, os OSName, v Version, pv platformVariant) Candidate {
+	c := Candidate{
+		Browser:         browser,
+		OS:              os,
+		Device:          bd.device,
+		Version:         v,
+		Channel:         bd.channel(v),
+		Engine:          bd.engine(v),
+		OSVersion:       pv.osVersion,
+		PlatformVersion: pv.platformVersion,
+		Architecture:    pv.arch,
+		Bitness:         pv.bitness,
+		Model:           pv.model,
+		FormFactor:      pv.formFactor,
+		weight:          pv.weight,
+	}
+	if released, depth := bd.releaseDate(v); depth > 0 {
+		c.Released = released
+	}
+	return c
+}
+
+// WithFilter only generates candidates for which keep returns true. Calling
+// it more than once requires every filter to pass, so rules like "Windows 11
+// only, ARM excluded" can be combined:
+//
+//	WithOSVersion("11"), WithFilter(func(c Candidate) bool { return c.Architecture != "arm" })
+func WithFilter(keep func(Candidate) bool) Option {
+	return func(o *generateOptions) {
+		o.filters = append(o.filters, keep)
+	}
+}
+
+// WithOSVersion only generates the given marketing OS versions, e.g. "11".
+func WithOSVersion(versions ...string) Option {
+	return WithFilter(func(c Candidate) bool { return contains(versions, c.OSVersion) })
+}
+
+// WithArchitecture only generates the given architectures, e.g. "arm".
+func WithArchitecture(archs ...string) Option {
+	return WithFilter(func(c Candidate) bool { return contains(archs, c.Architecture) })
+}
+
+// WithDeviceModel only generates the given device models, e.g. "Pixel 8".
+func WithDeviceModel(models ...string) Option {
+	return WithFilter(func(c Candidate) bool { return contains(models, c.Model) })
+}
+
+// WithFormFactor only generates the given form factors, e.g. "Desktop".
+func WithFormFactor(factors ...string) Option {
+	return WithFilter(func(c Candidate) bool { return contains(factors, c.FormFactor) })
+}
+
+// WithChannel only generates versions of the given release channels.
+func WithChannel(channels ...string) Option {
+	return WithFilter(func(c Candidate) bool { return contains(channels, c.Channel) })
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Candidates returns every candidate Generate may produce with opts, newest
+// version first. It is meant for inspecting the effect of filters. The
+// candidates are copies and may be modified.
+func (g *Generator) Candidates(opts ...Option) ([]Candidate, error) {
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	choices, err := g.platformChoices(options)
+	if err != nil {
+		return nil, err
+	}
+	var all []Candidate
+	for _, c := range choices {
+		for _, group := range c.candidates {
+			for _, cand := range group {
+				cand.Version = cand.Version.clone()
+				all = append(all, cand)
+			}
+		}
+	}
+	return all, nil
+}
+
+// filterCandidates returns the versions of bd that have candidates passing
+// the filters, newest first, and those candidates grouped by version. The
+// groups may share memory with bd and must not be modified.
+func (g *Generator) filterCandidates(bd *browserData, opts *generateOptions) ([]Version, [][]Candidate, error) {
+	constraints, err := opts.resolveConstraints(bd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	table := bd.candidateTable()
+	var versions []Version
+	var groups [][]Candidate
+	for i, v := range bd.versions {
+		// Min version check
+		if len(opts.minVersion.Components) > 0 && v.Compare(opts.minVersion) < 0 {
+			continue
+		}
+		// Max version check
+		if len(opts.maxVersion.Components) > 0 && v.Compare(opts.maxVersion) > 0 {
+			continue
+		}
+		if !satisfiesAll(constraints, v) {
+			continue
+		}
+		if bd.isDenied(v) || matchesAny(opts.exclude, v) {
+			continue
+		}
+		// Release date checks; versions without a known date never pass
+		if opts.hasDateFilter() {
+			released := table[i][0].Released
+			if released.IsZero() || !opts.releasedInRange(released) {
+				continue
+			}
+		}
+
+		group := table[i]
+		if len(opts.filters) > 0 {
+			group = nil
+			for _, c := range table[i] {
+				if opts.keep(c) {
+					group = append(group, c)
+				}
+			}
+			if len(group) == 0 {
+				continue
+			}
+		}
+		versions = append(versions, v)
+		groups = append(groups, group)
+	}
+	return versions, groups, nil
+}
+
+// keep reports whether c passes every WithFilter predicate.
+func (o *generateOptions) keep(c Candidate) bool {
+	for _, f := range o.filters {
+		if !f(c) {
+			return false
+		}
+	}
+	return true
+}
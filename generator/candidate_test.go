@@ -0,0 +1,70 @@
+package useragent
+
+import (
+	"testing"
+)
+
+func TestCandidates(t *testing.T) {
+	g, err := NewWithSeed(11)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	t.Run("Windows11NoARM", func(t *testing.T) {
+		opts := []Option{
+			WithOSVersion("11"),
+			WithFilter(func(c Candidate) bool { return c.Architecture != "arm" }),
+			WithAllClientHints(),
+		}
+		for i := 0; i < 50; i++ {
+			res, err := g.Generate(opts...)
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if res.Candidate.OSVersion != "11" || res.Hints.Architecture != "x86" {
+				t.Fatalf("Unexpected variant %+v", res.Candidate)
+			}
+			if got := res.Headers["Sec-CH-UA-Platform-Version"]; got != `"15.0.0"` {
+				t.Fatalf("Expected Windows 11 platform version, got %s", got)
+			}
+			if findings := Validate(res.Headers); HasErrors(findings) {
+				t.Fatalf("Validation failed: %v", findings)
+			}
+		}
+	})
+
+	t.Run("DeviceModel", func(t *testing.T) {
+		res, err := g.Generate(WithOS(Android), WithDeviceModel("Pixel 9"), WithAllClientHints())
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.Headers["Sec-CH-UA-Model"] != `"Pixel 9"` || res.Candidate.OSVersion != "15" {
+			t.Errorf("Unexpected model headers %v", res.Headers)
+		}
+	})
+
+	t.Run("Candidates", func(t *testing.T) {
+		all, err := g.Candidates(WithOS(MacOS), WithVersionConstraint("^140"))
+		if err != nil {
+			t.Fatalf("Candidates failed: %v", err)
+		}
+		arm, err := g.Candidates(WithOS(MacOS), WithVersionConstraint("^140"), WithArchitecture("arm"))
+		if err != nil {
+			t.Fatalf("Candidates failed: %v", err)
+		}
+		if len(arm) == 0 || len(arm) >= len(all) {
+			t.Fatalf("Expected a strict subset, got %d of %d", len(arm), len(all))
+		}
+		for _, c := range all {
+			if c.Channel != ChannelStable || majorComponent(c.Version) != 140 {
+				t.Fatalf("Unexpected candidate %+v", c)
+			}
+		}
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		if _, err := g.Generate(WithChannel("beta")); err == nil {
+			t.Error("Expected error when no candidate passes the filters")
+		}
+	})
+}
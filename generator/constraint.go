@@ -0,0 +1,319 @@
+package useragent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Constraint is a parsed version constraint expression such as
+// ">=133 <136 || ^138". Whitespace (or a comma) separates terms that must all
+// hold, "||" separates alternatives. Supported terms:
+//
+//	>=V >V <=V <V   comparisons; missing components count as 0
+//	=V !=V          exact (in)equality, "=133" is 133.0.0.0
+//	^V              same major as V and at least V
+//	~V              at least V, below V with its second-to-last component
+//	                incremented: ~134.0.6998.100 is >=134.0.6998.100 <134.0.6999
+//	                and ~134.5 is >=134.5 <135
+//	V, V.x          prefix match: 134 and 134.x both mean any 134 build
+//
+// V may also be a relative selector (see WithMinVersion) such as "latest-2",
+// which denotes a whole major version: ">=latest-2" allows the two majors
+// before the newest and everything after, "<=latest-1" includes every build
+// of the previous major. Selectors are resolved against the data of each
+// browser/OS pair at generation time; Check treats them as unsatisfied.
+type Constraint struct {
+	expr string
+	alts [][]term // OR of ANDs
+}
+
+// term is a single comparison against a version.
+type term struct {
+	op       string // One of >=, >, <=, <, =, != or !~ (no prefix match)
+	version  Version
+	selector string // Relative selector, resolved into version by resolve
+}
+
+// ConstraintError describes a syntax error in a constraint expression.
+type ConstraintError struct {
+	Expr string
+	Term string
+	Msg  string
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Term == "" {
+		return fmt.Sprintf("invalid version constraint %q: %s", e.Expr, e.Msg)
+	}
+	return fmt.Sprintf("invalid version constraint %q: term %q: %s", e.Expr, e.Term, e.Msg)
+}
+
+// ParseConstraint parses a constraint expression.
+func ParseConstraint(expr string) (Constraint, error) {
+	c := Constraint{expr: expr}
+	if strings.TrimSpace(expr) == "" {
+		return c, &ConstraintError{Expr: expr, Msg: "empty expression"}
+	}
+
+	for _, alt := range strings.Split(expr, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+		if len(fields) == 0 {
+			return c, &ConstraintError{Expr: expr, Msg: "empty alternative around \"||\""}
+		}
+
+		var terms []term
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// Allow a space between operator and version: ">= 133"
+			if isOperator(field) && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+			parsed, err := parseTerm(field)
+			if err != nil {
+				return c, &ConstraintError{Expr: expr, Term: field, Msg: err.Error()}
+			}
+			terms = append(terms, parsed...)
+		}
+		c.alts = append(c.alts, terms)
+	}
+	return c, nil
+}
+
+// MustParseConstraint is like ParseConstraint but panics on error.
+// It is intended for constraints known at compile time.
+func MustParseConstraint(expr string) Constraint {
+	c, err := ParseConstraint(expr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String returns the expression the constraint was parsed from.
+func (c Constraint) String() string {
+	return c.expr
+}
+
+// Check reports whether v satisfies the constraint.
+func (c Constraint) Check(v Version) bool {
+	for _, alt := range c.alts {
+		ok := true
+		for _, t := range alt {
+			if !t.check(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (t term) check(v Version) bool {
+	if t.selector != "" {
+		return false
+	}
+	if t.op == "!~" {
+		return !hasPrefix(v, t.version)
+	}
+	cmp := v.Compare(t.version)
+	switch t.op {
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	}
+	return false
+}
+
+var operators = []string{">=", "<=", "!=", ">", "<", "=", "^", "~"}
+
+func isOperator(s string) bool {
+	for _, op := range operators {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTerm expands a single term into comparisons.
+func parseTerm(s string) ([]term, error) {
+	op := ""
+	for _, candidate := range operators {
+		if strings.HasPrefix(s, candidate) {
+			op = candidate
+			break
+		}
+	}
+	raw := strings.TrimPrefix(s, op)
+	if raw == "" {
+		return nil, fmt.Errorf("missing version")
+	}
+	if isSelector(raw) {
+		if err := checkSelector(raw); err != nil {
+			return nil, err
+		}
+		return []term{{op: op, selector: raw}}, nil
+	}
+
+	v, wildcard, err := parseConstraintVersion(raw)
+	if err != nil {
+		return nil, err
+	}
+	if wildcard && op != "" {
+		return nil, fmt.Errorf("wildcards cannot be combined with %q", op)
+	}
+
+	switch op {
+	case "^":
+		return []term{{op: ">=", version: v}, {op: "<", version: Version{Components: []int{v.Components[0] + 1}}}}, nil
+	case "~":
+		return tildeRange(v), nil
+	case "":
+		return prefixRange(v), nil
+	default:
+		return []term{{op: op, version: v}}, nil
+	}
+}
+
+// prefixRange matches every version starting with the components of v.
+func prefixRange(v Version) []term {
+	upper := append([]int(nil), v.Components...)
+	upper[len(upper)-1]++
+	return []term{{op: ">=", version: v}, {op: "<", version: Version{Components: upper}}}
+}
+
+// tildeRange matches v and later versions up to the next increment of the
+// second-to-last component of v. A single component is treated as a major.
+func tildeRange(v Version) []term {
+	n := len(v.Components) - 1
+	if n == 0 {
+		n = 1
+	}
+	upper := append([]int(nil), v.Components[:n]...)
+	upper[n-1]++
+	return []term{{op: ">=", version: v}, {op: "<", version: Version{Components: upper}}}
+}
+
+// parseConstraintVersion parses a version that may end in wildcard
+// components ("134.x", "134.*").
+func parseConstraintVersion(s string) (Version, bool, error) {
+	parts := strings.Split(s, ".")
+	var components []int
+	wildcard := false
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			if i == 0 {
+				return Version{}, false, fmt.Errorf("major version cannot be a wildcard")
+			}
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return Version{}, false, fmt.Errorf("component %q follows a wildcard", p)
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || strings.HasPrefix(p, "+") {
+			return Version{}, false, fmt.Errorf("component %q is not a number", p)
+		}
+		components = append(components, n)
+	}
+	return Version{Components: components}, wildcard, nil
+}
+
+// relative reports whether the constraint uses relative selectors.
+func (c Constraint) relative() bool {
+	for _, alt := range c.alts {
+		for _, t := range alt {
+			if t.selector != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolve replaces relative selectors with the versions they denote in bd.
+func (c Constraint) resolve(bd *browserData, now time.Time) (Constraint, error) {
+	out := Constraint{expr: c.expr, alts: make([][]term, len(c.alts))}
+	for i, alt := range c.alts {
+		for _, t := range alt {
+			if t.selector == "" {
+				out.alts[i] = append(out.alts[i], t)
+				continue
+			}
+			major, err := resolveSelector(t.selector, bd, now)
+			if err != nil {
+				return Constraint{}, err
+			}
+			out.alts[i] = append(out.alts[i], majorTerms(t.op, major)...)
+		}
+	}
+	return out, nil
+}
+
+// majorTerms expands a comparison against a whole major version.
+func majorTerms(op string, major int) []term {
+	lower := Version{Components: []int{major}}
+	upper := Version{Components: []int{major + 1}}
+	switch op {
+	case ">=":
+		return []term{{op: ">=", version: lower}}
+	case ">":
+		return []term{{op: ">=", version: upper}}
+	case "<=":
+		return []term{{op: "<", version: upper}}
+	case "<":
+		return []term{{op: "<", version: lower}}
+	case "!=":
+		return []term{{op: "!~", version: lower}}
+	default:
+		return []term{{op: ">=", version: lower}, {op: "<", version: upper}}
+	}
+}
+
+func hasPrefix(v, prefix Version) bool {
+	if len(v.Components) < len(prefix.Components) {
+		return false
+	}
+	for i, c := range prefix.Components {
+		if v.Components[i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether v equals or starts with one of the prefixes.
+func matchesAny(prefixes []Version, v Version) bool {
+	for _, p := range prefixes {
+		if hasPrefix(v, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfiesAll reports whether v satisfies every constraint.
+func satisfiesAll(constraints []Constraint, v Version) bool {
+	for _, c := range constraints {
+		if !c.Check(v) {
+			return false
+		}
+	}
+	return true
+}
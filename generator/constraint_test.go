@@ -0,0 +1,152 @@
+package useragent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConstraint(t *testing.T) {
+	t.Run("Check", func(t *testing.T) {
+		cases := []struct {
+			expr    string
+			version string
+			want    bool
+		}{
+			{">=133 <136", "135.0.7049.42", true},
+			{">=133 <136", "136.0.7103.48", false},
+			{">=133, <136", "133.0.6943.53", true},
+			{">= 133 < 136", "132.0.6834.110", false},
+			{"^134", "134.0.6998.165", true},
+			{"^134", "135.0.7049.42", false},
+			{"^134.0.6998.100", "134.0.6998.88", false},
+			{"~134.0.6998.100", "134.0.6998.165", true},
+			{"~134.0.6998.100", "134.0.6998.88", false},
+			{"~134.0.6998.100", "134.0.6999.0", false},
+			{"~134.0.6998", "134.0.7049.0", true},
+			{"~134.0.6998", "134.1.0.0", false},
+			{"~134.5", "134.9.7000.1", true},
+			{"~134.5", "134.4.9999.0", false},
+			{"~134.5", "135.0.0.0", false},
+			{"~134", "134.0.6998.165", true},
+			{"~134", "135.0.0.0", false},
+			{"!=135.0.7049.42", "135.0.7049.42", false},
+			{"!=135.0.7049.42", "135.0.7049.52", true},
+			{"=133", "133.0.0.0", true},
+			{"=133", "133.0.6943.53", false},
+			{"134", "134.0.6998.165", true},
+			{"134.x", "135.0.7049.42", false},
+			{"^133 || ^138", "138.0.7204.49", true},
+			{"^133 || ^138", "136.0.7103.48", false},
+			{">=136 !=137 || =133.0.6943.53", "137.0.7151.55", true},
+		}
+		for _, tc := range cases {
+			c, err := ParseConstraint(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) failed: %v", tc.expr, err)
+			}
+			v, err := ParseVersion(tc.version)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) failed: %v", tc.version, err)
+			}
+			if got := c.Check(v); got != tc.want {
+				t.Errorf("%q.Check(%s) = %v, want %v", tc.expr, tc.version, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("ParseErrors", func(t *testing.T) {
+		for _, expr := range []string{"", ">=", ">=13x", "^134 ||", "x.1", "134.x.5", ">=134.x", "<=-1", "=>133"} {
+			_, err := ParseConstraint(expr)
+			var cerr *ConstraintError
+			if !errors.As(err, &cerr) {
+				t.Errorf("ParseConstraint(%q) = %v, want ConstraintError", expr, err)
+			}
+		}
+
+		_, err := ParseConstraint(">=133 <13x6")
+		if err == nil || !strings.Contains(err.Error(), `"13x6"`) {
+			t.Errorf("Error should name the bad component, got %v", err)
+		}
+	})
+
+	t.Run("Generate", func(t *testing.T) {
+		g, err := NewWithSeed(3)
+		if err != nil {
+			t.Fatalf("Failed to create generator: %v", err)
+		}
+		c := MustParseConstraint("^134 || ~136.0.7103")
+		for i := 0; i < 50; i++ {
+			res, err := g.Generate(WithVersionConstraint("^134 || ~136.0.7103"))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if !c.Check(res.Version) {
+				t.Fatalf("Version %s does not satisfy %s", res.Version, c)
+			}
+		}
+
+		// Repeated constraints must all hold
+		res, err := g.Generate(WithVersionConstraint(">=134"), WithVersionConstraint("<135"))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.Version.Components[0] != 134 {
+			t.Errorf("Expected major 134, got %s", res.Version)
+		}
+
+		if _, err := g.Generate(WithVersionConstraint(">=abc")); err == nil {
+			t.Error("Expected parse error from Generate")
+		}
+	})
+	t.Run("Selectors", func(t *testing.T) {
+		g, err := NewWithSeed(5)
+		if err != nil {
+			t.Fatalf("Failed to create generator: %v", err)
+		}
+		latest := majorComponent(g.data().data[Chrome][Windows].versions[0])
+		ref := WithReferenceTime(time.Date(2025, 9, 10, 0, 0, 0, 0, time.UTC))
+
+		cases := []struct {
+			name     string
+			opts     []Option
+			min, max int
+		}{
+			{"Latest", []Option{WithVersionConstraint("latest")}, latest, latest},
+			{"LatestN", []Option{WithMinVersion("latest-2")}, latest - 2, latest},
+			{"PreviousMajor", []Option{WithMaxVersion("previous-major")}, 0, latest - 1},
+			{"Range", []Option{WithVersionConstraint(">=latest-3 <latest-1")}, latest - 3, latest - 2},
+			{"Exclude", []Option{WithVersionConstraint(">=latest-1 !=latest")}, latest - 1, latest - 1},
+			// 139 reached end of life when 140 was released on 2025-09-02
+			{"OldestSupported", []Option{ref, WithMinVersion("oldest-supported"), WithVersionConstraint("<141")}, 140, 140},
+		}
+		for _, tc := range cases {
+			for i := 0; i < 30; i++ {
+				res, err := g.Generate(tc.opts...)
+				if err != nil {
+					t.Fatalf("%s: Generate failed: %v", tc.name, err)
+				}
+				if m := majorComponent(res.Version); m < tc.min || m > tc.max {
+					t.Fatalf("%s: major %d outside [%d, %d]", tc.name, m, tc.min, tc.max)
+				}
+			}
+		}
+
+		// A later numeric bound replaces a selector
+		res, err := g.Generate(WithMinVersion("latest"), WithMinVersion("133"), WithMaxVersion("133.0.6943.999"))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if majorComponent(res.Version) != 133 {
+			t.Errorf("Expected major 133, got %s", res.Version)
+		}
+
+		if _, err := g.Generate(WithMinVersion("newest")); err == nil {
+			t.Error("Expected error for unknown selector")
+		}
+		if _, err := g.Generate(WithVersionConstraint(">=latest-999")); err == nil {
+			t.Error("Expected error for selector beyond the data")
+		}
+	})
+}
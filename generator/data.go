@@ -1,36 +1,234 @@
 package useragent
 
 import (
-	_ "embed"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
-
-	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-//go:embed browsers.yaml
-var browsersYAML []byte
+//go:generate go run ../cmd/compile-data -in browsers.yaml -out data_gen.go
+
+// dateLayout is the format of dates in the data file.
+const dateLayout = "2006-01-02"
 
 // browserData holds the flattened data for internal use.
 type browserData struct {
-	versions   []Version
-	uaTemplate string
+	browser    BrowserName
+	os         OSName
+	versions   []Version // Newest first; may be shared with the compiled tables
+	denied     []Version // Denylisted versions and version prefixes
+	uaTemplate *uaTemplate
+	device     DeviceClass
+	variants   []platformVariant    // Never empty
+	released   map[string]time.Time // release dates keyed by version prefix, e.g. "133"
+	eol        map[string]time.Time // end-of-life dates keyed by version prefix
+	channels   map[string]string    // release channels keyed by version prefix
+	engines    map[string]string    // engine versions keyed by version prefix
+	overrides  []versionOverride    // Applied in order, later ones win
+
+	// candidates[i] holds the candidates of versions[i], one per variant;
+	// built on first use by candidateTable and never modified afterwards.
+	candidates     [][]Candidate
+	candidatesOnce sync.Once
+}
+
+// candidateTable returns the candidates of every version, building them on
+// first use so that New stays cheap and generation does not repeat
+// metadata lookups.
+func (bd *browserData) candidateTable() [][]Candidate {
+	bd.candidatesOnce.Do(func() {
+		bd.candidates = make([][]Candidate, len(bd.versions))
+		for i, v := range bd.versions {
+			bd.candidates[i] = make([]Candidate, len(bd.variants))
+			for j, pv := range bd.variants {
+				bd.candidates[i][j] = bd.candidate(bd.browser, bd.os, v, pv)
+			}
+		}
+	})
+	return bd.candidates
+}
+
+// releaseDate returns the release date of v, taken from the most specific
+// version prefix that has one, and the length of that prefix (0 if unknown).
+func (bd *browserData) releaseDate(v Version) (time.Time, int) {
+	return lookupDate(bd.released, v)
+}
+
+// isDenied reports whether v is denylisted.
+func (bd *browserData) isDenied(v Version) bool {
+	return matchesAny(bd.denied, v)
+}
+
+// endOfLife returns the date v stopped receiving updates, if known.
+func (bd *browserData) endOfLife(v Version) (time.Time, bool) {
+	t, n := lookupDate(bd.eol, v)
+	return t, n > 0
+}
+
+// channel returns the release channel of v.
+func (bd *browserData) channel(v Version) string {
+	if c, ok := lookupString(bd.channels, v); ok {
+		return c
+	}
+	return ChannelStable
+}
+
+// engine returns the rendering engine version of v, empty when unknown.
+func (bd *browserData) engine(v Version) string {
+	e, _ := lookupString(bd.engines, v)
+	return e
+}
+
+func lookupString(values map[string]string, v Version) (string, bool) {
+	for n := len(v.Components); n > 0; n-- {
+		prefix := Version{Components: v.Components[:n]}
+		if s, ok := values[prefix.String()]; ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func lookupDate(dates map[string]time.Time, v Version) (time.Time, int) {
+	for n := len(v.Components); n > 0; n-- {
+		prefix := Version{Components: v.Components[:n]}
+		if t, ok := dates[prefix.String()]; ok {
+			return t, n
+		}
+	}
+	return time.Time{}, 0
 }
 
 // dataStore holds all loaded browser data.
 type dataStore struct {
-	data map[BrowserName]map[OSName]*browserData
+	data        map[BrowserName]map[OSName]*browserData
+	marketShare []MarketShare
+	config      *Config // The configuration the store was built from
+}
+
+// lookup returns the data for a browser/OS pair.
+func (s *dataStore) lookup(browser BrowserName, os OSName) (*browserData, error) {
+	platforms, ok := s.data[browser]
+	if !ok {
+		return nil, fmt.Errorf("browser %s not found", browser)
+	}
+	bd, ok := platforms[os]
+	if !ok {
+		return nil, fmt.Errorf("os %s not found for browser %s", os, browser)
+	}
+	return bd, nil
+}
+
+// parseData parses YAML data in the format of browsers.yaml.
+func parseData(content []byte) (*dataStore, error) {
+	config, err := decodeConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	return buildStore(config)
+}
+
+var (
+	yamlDecoder func(data []byte, v interface{}) error
+	yamlLocator func(data []byte, path []string) (line, column int)
+)
+
+// RegisterYAMLDecoder installs the function used to decode YAML data. The
+// embedded data is compiled into Go tables, so YAML support is optional and
+// kept out of binaries that do not need it; importing the yamldata package
+// registers it:
+//
+//	import _ "github.com/r1x0s/go-useragent-utils/generator/yamldata"
+//
+// The decoder may return DataErrors for values it skipped; the data is then
+// still validated but cannot be loaded.
+func RegisterYAMLDecoder(decode func(data []byte, v interface{}) error) {
+	yamlDecoder = decode
+}
+
+// RegisterYAMLLocator installs the function ValidateData uses to find the
+// line and column of a path in YAML or JSON data. The yamldata package
+// registers it along with the decoder.
+func RegisterYAMLLocator(locate func(data []byte, path []string) (line, column int)) {
+	yamlLocator = locate
 }
 
-// loadData parses the embedded YAML and returns a structured data store.
-func loadData() (*dataStore, error) {
+// errNoYAML is returned when YAML data is loaded without a registered decoder.
+var errNoYAML = errors.New("decoding YAML data requires importing github.com/r1x0s/go-useragent-utils/generator/yamldata")
+
+// decodeConfig decodes data in the format of browsers.yaml, written as YAML
+// or, when it starts with "{", as JSON.
+func decodeConfig(content []byte) (*Config, error) {
 	var config Config
-	if err := yaml.Unmarshal(browsersYAML, &config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal embedded data: %w", err)
+	err := decodeData(content, &config)
+	var problems DataErrors
+	if errors.As(err, &problems) {
+		// The rest of the data was decoded; ValidateData reports the
+		// problems and buildStore refuses the config.
+		config.decodeErrs = problems
+	} else if err != nil {
+		return nil, err
+	}
+	if yamlLocator != nil {
+		// JSON is valid YAML, so the locator handles both
+		config.locate = func(path []string) (int, int) { return yamlLocator(content, path) }
+	}
+	return &config, nil
+}
+
+// decodeData decodes YAML or, when it starts with "{", JSON into v. Values
+// that do not fit their field, such as a version key that is not an
+// integer, are skipped and returned as DataErrors after the rest of the data
+// is decoded; the JSON decoder only reports the first.
+func decodeData(content []byte, v interface{}) error {
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		err := json.Unmarshal(trimmed, v)
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return DataErrors{{
+				Path: strings.Split(typeErr.Field, "."),
+				Msg:  fmt.Sprintf("cannot unmarshal %s into %s", typeErr.Value, typeErr.Type),
+			}}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal JSON data: %w", err)
+		}
+		return nil
+	}
+	if yamlDecoder == nil {
+		return errNoYAML
+	}
+	if err := yamlDecoder(content, v); err != nil {
+		var problems DataErrors
+		if errors.As(err, &problems) {
+			return problems
+		}
+		return fmt.Errorf("failed to unmarshal data: %w", err)
+	}
+	return nil
+}
+
+// buildStore validates a configuration and flattens it into a data store.
+func buildStore(config *Config) (*dataStore, error) {
+	if len(config.decodeErrs) > 0 {
+		return nil, fmt.Errorf("failed to decode data: %w", config.decodeErrs)
+	}
+	if config.SchemaVersion > CurrentSchemaVersion {
+		return nil, fmt.Errorf("data schema version %d is newer than the supported version %d, update this module", config.SchemaVersion, CurrentSchemaVersion)
+	}
+	if config.SchemaVersion < 0 {
+		return nil, fmt.Errorf("invalid data schema version %d", config.SchemaVersion)
 	}
 
 	store := &dataStore{
-		data: make(map[BrowserName]map[OSName]*browserData),
+		data:   make(map[BrowserName]map[OSName]*browserData),
+		config: config,
 	}
 
 	for browserStr, platforms := range config.Browsers {
@@ -41,22 +239,165 @@ func loadData() (*dataStore, error) {
 			osName := OSName(osStr)
 
 			bd := &browserData{
-				uaTemplate: pConfig.UATemplate,
-				versions:   make([]Version, 0),
+				browser:  browser,
+				os:       osName,
+				device:   pConfig.Device,
+				released: make(map[string]time.Time),
+				eol:      make(map[string]time.Time),
+				channels: make(map[string]string),
+				engines:  make(map[string]string),
+			}
+			switch bd.device {
+			case "":
+				bd.device = Desktop
+			case Desktop, Mobile, Tablet:
+			default:
+				return nil, fmt.Errorf("%s/%s: unknown device class %q", browser, osName, bd.device)
+			}
+			tmpl, err := parseTemplate(pConfig.UATemplate)
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", browser, osName, err)
+			}
+			bd.uaTemplate = tmpl
+			variants, err := resolveVariants(osName, bd.device, pConfig.Variants)
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", browser, osName, err)
+			}
+			bd.variants = variants
+			if bd.overrides, err = parseOverrides(pConfig.Overrides); err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", browser, osName, err)
+			}
+
+			// Compiled tables come pre-parsed and sorted
+			if compiled, ok := config.compiled[browserStr+"/"+osStr]; ok {
+				bd.versions = compiled
+			} else {
+				versions, err := pConfig.AllVersions()
+				if err != nil {
+					return nil, fmt.Errorf("%s/%s: %w", browser, osName, err)
+				}
+				bd.versions = versions
 			}
 
-			// Recursively parse versions
-			bd.versions = parseVersions([]int{}, pConfig.Versions)
+			// Flat builds contribute per-build metadata
+			metadata := pConfig.Metadata
+			if len(pConfig.Builds) > 0 {
+				metadata = make(map[string]VersionMeta, len(pConfig.Metadata)+len(pConfig.Builds))
+				for key, meta := range pConfig.Metadata {
+					metadata[key] = meta
+				}
+				for _, b := range pConfig.Builds {
+					if b.VersionMeta != (VersionMeta{}) {
+						v, _ := ParseVersion(b.Version) // Validated by AllVersions
+						metadata[v.String()] = b.VersionMeta
+					}
+				}
+			}
+
+			for key, meta := range metadata {
+				prefix, err := ParseVersion(key)
+				if err != nil {
+					return nil, fmt.Errorf("%s/%s metadata: %w", browser, osName, err)
+				}
+				if meta.Released != "" {
+					t, err := time.Parse(dateLayout, meta.Released)
+					if err != nil {
+						return nil, fmt.Errorf("%s/%s metadata for %s: invalid release date %q", browser, osName, key, meta.Released)
+					}
+					bd.released[prefix.String()] = t
+				}
+				if meta.EOL != "" {
+					t, err := time.Parse(dateLayout, meta.EOL)
+					if err != nil {
+						return nil, fmt.Errorf("%s/%s metadata for %s: invalid end-of-life date %q", browser, osName, key, meta.EOL)
+					}
+					bd.eol[prefix.String()] = t
+				}
+				if meta.Channel != "" {
+					bd.channels[prefix.String()] = meta.Channel
+				}
+				if meta.Engine != "" {
+					bd.engines[prefix.String()] = meta.Engine
+				}
+			}
+
+			store.data[browser][osName] = bd
+		}
+	}
 
-			// Sort versions descending (newest first)
+	// Platforms that share another platform's release train reuse its versions.
+	for browserStr, platforms := range config.Browsers {
+		browser := BrowserName(browserStr)
+		for osStr, pConfig := range platforms {
+			if pConfig.VersionsFrom == "" {
+				continue
+			}
+			bd := store.data[browser][OSName(osStr)]
+			source, ok := store.data[browser][OSName(pConfig.VersionsFrom)]
+			if !ok || source == bd {
+				return nil, fmt.Errorf("%s/%s: versions_from references unknown platform %q", browser, osStr, pConfig.VersionsFrom)
+			}
+			if len(source.versions) == 0 {
+				return nil, fmt.Errorf("%s/%s: versions_from platform %q has no versions of its own", browser, osStr, pConfig.VersionsFrom)
+			}
+			// Copy so the compiled tables are never modified
+			bd.versions = append(append([]Version(nil), bd.versions...), source.versions...)
 			sort.Slice(bd.versions, func(i, j int) bool {
 				return bd.versions[i].Compare(bd.versions[j]) > 0
 			})
+			for key, t := range source.released {
+				if _, ok := bd.released[key]; !ok {
+					bd.released[key] = t
+				}
+			}
+			for key, t := range source.eol {
+				if _, ok := bd.eol[key]; !ok {
+					bd.eol[key] = t
+				}
+			}
+			for key, c := range source.channels {
+				if _, ok := bd.channels[key]; !ok {
+					bd.channels[key] = c
+				}
+			}
+			for key, e := range source.engines {
+				if _, ok := bd.engines[key]; !ok {
+					bd.engines[key] = e
+				}
+			}
+		}
+	}
 
-			store.data[browser][osName] = bd
+	for browserStr, entries := range config.Denylist {
+		platforms, ok := store.data[BrowserName(browserStr)]
+		if !ok {
+			return nil, fmt.Errorf("denylist references unknown browser %q", browserStr)
+		}
+		for _, entry := range entries {
+			v, err := ParseVersion(entry.Version)
+			if err != nil {
+				return nil, fmt.Errorf("%s denylist: %w", browserStr, err)
+			}
+			if entry.OS != "" {
+				if _, ok := platforms[entry.OS]; !ok {
+					return nil, fmt.Errorf("%s denylist entry %s references unknown os %q", browserStr, entry.Version, entry.OS)
+				}
+			}
+			for osName, bd := range platforms {
+				if entry.OS == "" || entry.OS == osName {
+					bd.denied = append(bd.denied, v)
+				}
+			}
 		}
 	}
 
+	for _, ms := range config.MarketShare {
+		if ms.Share < 0 {
+			return nil, fmt.Errorf("market share for %s/%s is negative", ms.Browser, ms.OS)
+		}
+	}
+	store.marketShare = append([]MarketShare(nil), config.MarketShare...)
+
 	return store, nil
 }
 
@@ -76,6 +417,13 @@ func parseVersions(prefix []int, current interface{}) []Version {
 		for key, val := range v {
 			results = append(results, parseVersions(makePrefix(prefix, key), val)...)
 		}
+	case map[string]interface{}:
+		// JSON object keys are always strings
+		for key, val := range v {
+			if keyInt, err := strconv.Atoi(key); err == nil {
+				results = append(results, parseVersions(makePrefix(prefix, keyInt), val)...)
+			}
+		}
 	case map[interface{}]interface{}:
 		// Handle case where yaml unmarshals keys as interface{}
 		for key, val := range v {
@@ -105,6 +453,21 @@ func parseVersions(prefix []int, current interface{}) []Version {
 	return results
 }
 
+// dedupeVersions removes repeated versions, e.g. a build listed both in the
+// version tree and in the flat list.
+func dedupeVersions(versions []Version) []Version {
+	seen := make(map[string]bool, len(versions))
+	out := versions[:0]
+	for _, v := range versions {
+		key := v.String()
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func toInt(i interface{}) (int, bool) {
 	switch v := i.(type) {
 	case int:
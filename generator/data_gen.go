@@ -0,0 +1,873 @@
+// Code generated by compile-data from browsers.yaml; DO NOT EDIT.
+
+package useragent
+
+// embeddedConfig returns the embedded data. Every call returns a new
+// Config; the version tables are shared and must not be modified.
+func embeddedConfig() *Config {
+	return &Config{
+		SchemaVersion: 1,
+		Browsers: map[string]map[string]PlatformConfig{
+			"chrome": {
+				"android": {
+					UATemplate:   "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{{reduced_version}} Mobile Safari/537.36",
+					Device:       "mobile",
+					VersionsFrom: "windows",
+					Variants: []PlatformVariant{
+						{OSVersion: "14", PlatformVersion: "14.0.0", Model: "Pixel 8", Weight: 0.3},
+						{OSVersion: "15", PlatformVersion: "15.0.0", Model: "Pixel 9", Weight: 0.2},
+						{OSVersion: "14", PlatformVersion: "14.0.0", Model: "SM-S918B", Weight: 0.3},
+						{OSVersion: "15", PlatformVersion: "15.0.0", Model: "SM-S928B", Weight: 0.2},
+					},
+				},
+				"linux": {
+					UATemplate:   "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{{reduced_version}} Safari/537.36",
+					VersionsFrom: "windows",
+				},
+				"macos": {
+					UATemplate:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{{reduced_version}} Safari/537.36",
+					VersionsFrom: "windows",
+					Variants: []PlatformVariant{
+						{OSVersion: "15", PlatformVersion: "15.5.0", Architecture: "arm", Weight: 0.7},
+						{OSVersion: "14", PlatformVersion: "14.7.0", Architecture: "arm", Weight: 0.2},
+						{OSVersion: "14", PlatformVersion: "14.7.0", Architecture: "x86", Weight: 0.1},
+					},
+				},
+				"windows": {
+					UATemplate: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{{reduced_version}} Safari/537.36",
+					Variants: []PlatformVariant{
+						{OSVersion: "10", PlatformVersion: "10.0.0", Weight: 0.4},
+						{OSVersion: "11", PlatformVersion: "15.0.0", Weight: 0.55},
+						{OSVersion: "11", PlatformVersion: "15.0.0", Architecture: "arm", Weight: 0.05},
+					},
+					Metadata: map[string]VersionMeta{
+						"133": {Released: "2025-02-04", EOL: "2025-03-04"},
+						"134": {Released: "2025-03-04", EOL: "2025-04-01"},
+						"135": {Released: "2025-04-01", EOL: "2025-04-29"},
+						"136": {Released: "2025-04-29", EOL: "2025-05-27"},
+						"137": {Released: "2025-05-27", EOL: "2025-06-24"},
+						"138": {Released: "2025-06-24", EOL: "2025-08-05"},
+						"139": {Released: "2025-08-05", EOL: "2025-09-02"},
+						"140": {Released: "2025-09-02", EOL: "2025-09-30"},
+						"141": {Released: "2025-09-30", EOL: "2025-10-28"},
+						"142": {Released: "2025-10-28", EOL: "2025-12-02"},
+						"143": {Released: "2025-12-02", EOL: "2026-01-13"},
+						"144": {Released: "2026-01-13"},
+					},
+				},
+			},
+		},
+		MarketShare: []MarketShare{
+			{Browser: "chrome", OS: "windows", Device: "", Share: 0.38},
+			{Browser: "chrome", OS: "android", Device: "mobile", Share: 0.3},
+			{Browser: "chrome", OS: "macos", Device: "", Share: 0.07},
+			{Browser: "chrome", OS: "linux", Device: "", Share: 0.02},
+		},
+		compiled: map[string][]Version{
+			"chrome/windows": versionsChromeWindows,
+		},
+	}
+}
+
+var versionsChromeWindows = []Version{
+	{Components: []int{144, 0, 7540, 0}},
+	{Components: []int{144, 0, 7535, 0}},
+	{Components: []int{144, 0, 7534, 0}},
+	{Components: []int{144, 0, 7533, 2}},
+	{Components: []int{144, 0, 7531, 0}},
+	{Components: []int{144, 0, 7530, 0}},
+	{Components: []int{144, 0, 7529, 0}},
+	{Components: []int{144, 0, 7528, 0}},
+	{Components: []int{144, 0, 7527, 0}},
+	{Components: []int{144, 0, 7526, 3}},
+	{Components: []int{144, 0, 7526, 0}},
+	{Components: []int{144, 0, 7525, 3}},
+	{Components: []int{144, 0, 7524, 0}},
+	{Components: []int{144, 0, 7523, 0}},
+	{Components: []int{144, 0, 7522, 0}},
+	{Components: []int{144, 0, 7521, 0}},
+	{Components: []int{144, 0, 7520, 0}},
+	{Components: []int{144, 0, 7519, 0}},
+	{Components: []int{144, 0, 7518, 0}},
+	{Components: []int{144, 0, 7517, 0}},
+	{Components: []int{144, 0, 7516, 0}},
+	{Components: []int{144, 0, 7515, 0}},
+	{Components: []int{144, 0, 7514, 0}},
+	{Components: []int{144, 0, 7513, 0}},
+	{Components: []int{144, 0, 7512, 1}},
+	{Components: []int{144, 0, 7511, 0}},
+	{Components: []int{144, 0, 7510, 0}},
+	{Components: []int{144, 0, 7509, 0}},
+	{Components: []int{144, 0, 7508, 0}},
+	{Components: []int{144, 0, 7507, 0}},
+	{Components: []int{144, 0, 7506, 0}},
+	{Components: []int{144, 0, 7505, 0}},
+	{Components: []int{144, 0, 7504, 0}},
+	{Components: []int{144, 0, 7503, 2}},
+	{Components: []int{144, 0, 7503, 0}},
+	{Components: []int{144, 0, 7502, 0}},
+	{Components: []int{144, 0, 7501, 2}},
+	{Components: []int{144, 0, 7500, 2}},
+	{Components: []int{144, 0, 7500, 0}},
+	{Components: []int{143, 0, 7499, 40}},
+	{Components: []int{143, 0, 7499, 25}},
+	{Components: []int{143, 0, 7499, 17}},
+	{Components: []int{143, 0, 7499, 5}},
+	{Components: []int{143, 0, 7499, 4}},
+	{Components: []int{143, 0, 7499, 0}},
+	{Components: []int{143, 0, 7498, 2}},
+	{Components: []int{143, 0, 7497, 0}},
+	{Components: []int{143, 0, 7496, 0}},
+	{Components: []int{143, 0, 7495, 0}},
+	{Components: []int{143, 0, 7494, 0}},
+	{Components: []int{143, 0, 7491, 0}},
+	{Components: []int{143, 0, 7490, 0}},
+	{Components: []int{143, 0, 7489, 0}},
+	{Components: []int{143, 0, 7488, 0}},
+	{Components: []int{143, 0, 7487, 0}},
+	{Components: []int{143, 0, 7486, 0}},
+	{Components: []int{143, 0, 7484, 0}},
+	{Components: []int{143, 0, 7483, 0}},
+	{Components: []int{143, 0, 7482, 0}},
+	{Components: []int{143, 0, 7481, 0}},
+	{Components: []int{143, 0, 7480, 0}},
+	{Components: []int{143, 0, 7479, 0}},
+	{Components: []int{143, 0, 7478, 0}},
+	{Components: []int{143, 0, 7477, 0}},
+	{Components: []int{143, 0, 7476, 0}},
+	{Components: []int{143, 0, 7475, 8}},
+	{Components: []int{143, 0, 7475, 7}},
+	{Components: []int{143, 0, 7475, 0}},
+	{Components: []int{143, 0, 7474, 0}},
+	{Components: []int{143, 0, 7473, 0}},
+	{Components: []int{143, 0, 7472, 0}},
+	{Components: []int{143, 0, 7471, 0}},
+	{Components: []int{143, 0, 7470, 0}},
+	{Components: []int{143, 0, 7469, 0}},
+	{Components: []int{143, 0, 7466, 0}},
+	{Components: []int{143, 0, 7465, 0}},
+	{Components: []int{143, 0, 7464, 0}},
+	{Components: []int{143, 0, 7463, 0}},
+	{Components: []int{143, 0, 7462, 0}},
+	{Components: []int{143, 0, 7461, 2}},
+	{Components: []int{143, 0, 7461, 0}},
+	{Components: []int{143, 0, 7459, 0}},
+	{Components: []int{143, 0, 7458, 0}},
+	{Components: []int{143, 0, 7457, 0}},
+	{Components: []int{143, 0, 7456, 2}},
+	{Components: []int{143, 0, 7456, 0}},
+	{Components: []int{143, 0, 7455, 0}},
+	{Components: []int{143, 0, 7454, 0}},
+	{Components: []int{143, 0, 7453, 0}},
+	{Components: []int{143, 0, 7452, 0}},
+	{Components: []int{143, 0, 7451, 0}},
+	{Components: []int{143, 0, 7450, 4}},
+	{Components: []int{143, 0, 7449, 0}},
+	{Components: []int{143, 0, 7448, 0}},
+	{Components: []int{143, 0, 7447, 0}},
+	{Components: []int{143, 0, 7446, 0}},
+	{Components: []int{142, 0, 7444, 175}},
+	{Components: []int{142, 0, 7444, 162}},
+	{Components: []int{142, 0, 7444, 61}},
+	{Components: []int{142, 0, 7444, 59}},
+	{Components: []int{142, 0, 7444, 52}},
+	{Components: []int{142, 0, 7444, 34}},
+	{Components: []int{142, 0, 7444, 23}},
+	{Components: []int{142, 0, 7444, 6}},
+	{Components: []int{142, 0, 7444, 3}},
+	{Components: []int{142, 0, 7444, 0}},
+	{Components: []int{142, 0, 7443, 0}},
+	{Components: []int{142, 0, 7442, 0}},
+	{Components: []int{142, 0, 7441, 0}},
+	{Components: []int{142, 0, 7440, 0}},
+	{Components: []int{142, 0, 7439, 0}},
+	{Components: []int{142, 0, 7438, 0}},
+	{Components: []int{142, 0, 7437, 0}},
+	{Components: []int{142, 0, 7436, 0}},
+	{Components: []int{142, 0, 7435, 0}},
+	{Components: []int{142, 0, 7434, 0}},
+	{Components: []int{142, 0, 7433, 0}},
+	{Components: []int{142, 0, 7432, 0}},
+	{Components: []int{142, 0, 7431, 0}},
+	{Components: []int{142, 0, 7429, 0}},
+	{Components: []int{142, 0, 7428, 0}},
+	{Components: []int{142, 0, 7427, 0}},
+	{Components: []int{142, 0, 7426, 0}},
+	{Components: []int{142, 0, 7425, 0}},
+	{Components: []int{142, 0, 7424, 0}},
+	{Components: []int{142, 0, 7423, 0}},
+	{Components: []int{142, 0, 7422, 0}},
+	{Components: []int{142, 0, 7421, 0}},
+	{Components: []int{142, 0, 7420, 5}},
+	{Components: []int{142, 0, 7420, 4}},
+	{Components: []int{142, 0, 7420, 2}},
+	{Components: []int{142, 0, 7420, 0}},
+	{Components: []int{142, 0, 7419, 3}},
+	{Components: []int{142, 0, 7419, 0}},
+	{Components: []int{142, 0, 7418, 3}},
+	{Components: []int{142, 0, 7417, 0}},
+	{Components: []int{142, 0, 7416, 0}},
+	{Components: []int{142, 0, 7415, 0}},
+	{Components: []int{142, 0, 7414, 0}},
+	{Components: []int{142, 0, 7413, 0}},
+	{Components: []int{142, 0, 7412, 0}},
+	{Components: []int{142, 0, 7411, 0}},
+	{Components: []int{142, 0, 7410, 0}},
+	{Components: []int{142, 0, 7409, 0}},
+	{Components: []int{142, 0, 7408, 0}},
+	{Components: []int{142, 0, 7407, 0}},
+	{Components: []int{142, 0, 7406, 0}},
+	{Components: []int{142, 0, 7405, 0}},
+	{Components: []int{142, 0, 7404, 0}},
+	{Components: []int{142, 0, 7403, 0}},
+	{Components: []int{142, 0, 7402, 0}},
+	{Components: []int{142, 0, 7401, 0}},
+	{Components: []int{142, 0, 7400, 0}},
+	{Components: []int{142, 0, 7399, 0}},
+	{Components: []int{142, 0, 7398, 0}},
+	{Components: []int{142, 0, 7397, 0}},
+	{Components: []int{142, 0, 7396, 0}},
+	{Components: []int{142, 0, 7395, 0}},
+	{Components: []int{142, 0, 7394, 0}},
+	{Components: []int{142, 0, 7393, 6}},
+	{Components: []int{142, 0, 7393, 0}},
+	{Components: []int{142, 0, 7392, 0}},
+	{Components: []int{142, 0, 7391, 0}},
+	{Components: []int{141, 0, 7390, 122}},
+	{Components: []int{141, 0, 7390, 78}},
+	{Components: []int{141, 0, 7390, 76}},
+	{Components: []int{141, 0, 7390, 65}},
+	{Components: []int{141, 0, 7390, 56}},
+	{Components: []int{141, 0, 7390, 54}},
+	{Components: []int{141, 0, 7390, 37}},
+	{Components: []int{141, 0, 7390, 30}},
+	{Components: []int{141, 0, 7390, 16}},
+	{Components: []int{141, 0, 7390, 7}},
+	{Components: []int{141, 0, 7390, 6}},
+	{Components: []int{141, 0, 7390, 2}},
+	{Components: []int{141, 0, 7390, 0}},
+	{Components: []int{141, 0, 7389, 0}},
+	{Components: []int{141, 0, 7388, 0}},
+	{Components: []int{141, 0, 7387, 0}},
+	{Components: []int{141, 0, 7386, 0}},
+	{Components: []int{141, 0, 7385, 0}},
+	{Components: []int{141, 0, 7384, 0}},
+	{Components: []int{141, 0, 7383, 0}},
+	{Components: []int{141, 0, 7382, 0}},
+	{Components: []int{141, 0, 7381, 3}},
+	{Components: []int{141, 0, 7381, 0}},
+	{Components: []int{141, 0, 7380, 0}},
+	{Components: []int{141, 0, 7379, 0}},
+	{Components: []int{141, 0, 7378, 3}},
+	{Components: []int{141, 0, 7378, 0}},
+	{Components: []int{141, 0, 7377, 0}},
+	{Components: []int{141, 0, 7376, 0}},
+	{Components: []int{141, 0, 7375, 3}},
+	{Components: []int{141, 0, 7374, 0}},
+	{Components: []int{141, 0, 7373, 0}},
+	{Components: []int{141, 0, 7372, 0}},
+	{Components: []int{141, 0, 7371, 0}},
+	{Components: []int{141, 0, 7370, 0}},
+	{Components: []int{141, 0, 7369, 0}},
+	{Components: []int{141, 0, 7368, 0}},
+	{Components: []int{141, 0, 7367, 0}},
+	{Components: []int{141, 0, 7366, 0}},
+	{Components: []int{141, 0, 7365, 0}},
+	{Components: []int{141, 0, 7364, 0}},
+	{Components: []int{141, 0, 7363, 0}},
+	{Components: []int{141, 0, 7362, 0}},
+	{Components: []int{141, 0, 7361, 0}},
+	{Components: []int{141, 0, 7360, 0}},
+	{Components: []int{141, 0, 7359, 0}},
+	{Components: []int{141, 0, 7358, 0}},
+	{Components: []int{141, 0, 7357, 0}},
+	{Components: []int{141, 0, 7354, 0}},
+	{Components: []int{141, 0, 7353, 0}},
+	{Components: []int{141, 0, 7352, 0}},
+	{Components: []int{141, 0, 7351, 0}},
+	{Components: []int{141, 0, 7350, 0}},
+	{Components: []int{141, 0, 7348, 0}},
+	{Components: []int{141, 0, 7347, 0}},
+	{Components: []int{141, 0, 7346, 0}},
+	{Components: []int{141, 0, 7345, 0}},
+	{Components: []int{141, 0, 7344, 0}},
+	{Components: []int{141, 0, 7343, 0}},
+	{Components: []int{141, 0, 7342, 0}},
+	{Components: []int{141, 0, 7341, 0}},
+	{Components: []int{141, 0, 7340, 0}},
+	{Components: []int{140, 0, 7339, 207}},
+	{Components: []int{140, 0, 7339, 185}},
+	{Components: []int{140, 0, 7339, 82}},
+	{Components: []int{140, 0, 7339, 81}},
+	{Components: []int{140, 0, 7339, 80}},
+	{Components: []int{140, 0, 7339, 41}},
+	{Components: []int{140, 0, 7339, 24}},
+	{Components: []int{140, 0, 7339, 16}},
+	{Components: []int{140, 0, 7339, 6}},
+	{Components: []int{140, 0, 7339, 5}},
+	{Components: []int{140, 0, 7339, 2}},
+	{Components: []int{140, 0, 7339, 0}},
+	{Components: []int{140, 0, 7338, 0}},
+	{Components: []int{140, 0, 7337, 0}},
+	{Components: []int{140, 0, 7336, 0}},
+	{Components: []int{140, 0, 7335, 0}},
+	{Components: []int{140, 0, 7334, 0}},
+	{Components: []int{140, 0, 7333, 0}},
+	{Components: []int{140, 0, 7331, 0}},
+	{Components: []int{140, 0, 7330, 0}},
+	{Components: []int{140, 0, 7329, 0}},
+	{Components: []int{140, 0, 7328, 0}},
+	{Components: []int{140, 0, 7327, 6}},
+	{Components: []int{140, 0, 7327, 0}},
+	{Components: []int{140, 0, 7326, 0}},
+	{Components: []int{140, 0, 7325, 0}},
+	{Components: []int{140, 0, 7324, 0}},
+	{Components: []int{140, 0, 7323, 0}},
+	{Components: []int{140, 0, 7322, 0}},
+	{Components: []int{140, 0, 7321, 0}},
+	{Components: []int{140, 0, 7320, 0}},
+	{Components: []int{140, 0, 7319, 0}},
+	{Components: []int{140, 0, 7318, 0}},
+	{Components: []int{140, 0, 7317, 0}},
+	{Components: []int{140, 0, 7316, 0}},
+	{Components: []int{140, 0, 7315, 0}},
+	{Components: []int{140, 0, 7314, 0}},
+	{Components: []int{140, 0, 7313, 0}},
+	{Components: []int{140, 0, 7312, 0}},
+	{Components: []int{140, 0, 7311, 0}},
+	{Components: []int{140, 0, 7310, 0}},
+	{Components: []int{140, 0, 7309, 0}},
+	{Components: []int{140, 0, 7308, 0}},
+	{Components: []int{140, 0, 7307, 0}},
+	{Components: []int{140, 0, 7305, 0}},
+	{Components: []int{140, 0, 7303, 0}},
+	{Components: []int{140, 0, 7302, 0}},
+	{Components: []int{140, 0, 7301, 0}},
+	{Components: []int{140, 0, 7300, 0}},
+	{Components: []int{140, 0, 7299, 0}},
+	{Components: []int{140, 0, 7298, 0}},
+	{Components: []int{140, 0, 7297, 0}},
+	{Components: []int{140, 0, 7296, 0}},
+	{Components: []int{140, 0, 7295, 0}},
+	{Components: []int{140, 0, 7294, 0}},
+	{Components: []int{140, 0, 7293, 0}},
+	{Components: []int{140, 0, 7292, 0}},
+	{Components: []int{140, 0, 7291, 0}},
+	{Components: []int{140, 0, 7290, 0}},
+	{Components: []int{140, 0, 7289, 0}},
+	{Components: []int{140, 0, 7288, 0}},
+	{Components: []int{140, 0, 7287, 0}},
+	{Components: []int{140, 0, 7286, 0}},
+	{Components: []int{140, 0, 7284, 0}},
+	{Components: []int{140, 0, 7283, 0}},
+	{Components: []int{140, 0, 7282, 0}},
+	{Components: []int{140, 0, 7281, 0}},
+	{Components: []int{140, 0, 7280, 0}},
+	{Components: []int{140, 0, 7279, 0}},
+	{Components: []int{140, 0, 7278, 0}},
+	{Components: []int{140, 0, 7277, 0}},
+	{Components: []int{140, 0, 7276, 0}},
+	{Components: []int{140, 0, 7275, 0}},
+	{Components: []int{140, 0, 7274, 0}},
+	{Components: []int{140, 0, 7273, 0}},
+	{Components: []int{140, 0, 7272, 0}},
+	{Components: []int{140, 0, 7271, 0}},
+	{Components: []int{140, 0, 7269, 0}},
+	{Components: []int{140, 0, 7268, 0}},
+	{Components: []int{140, 0, 7267, 0}},
+	{Components: []int{140, 0, 7266, 0}},
+	{Components: []int{140, 0, 7265, 0}},
+	{Components: []int{140, 0, 7264, 3}},
+	{Components: []int{140, 0, 7264, 0}},
+	{Components: []int{140, 0, 7263, 0}},
+	{Components: []int{140, 0, 7262, 0}},
+	{Components: []int{140, 0, 7261, 0}},
+	{Components: []int{140, 0, 7260, 0}},
+	{Components: []int{140, 0, 7259, 2}},
+	{Components: []int{140, 0, 7259, 0}},
+	{Components: []int{139, 0, 7258, 154}},
+	{Components: []int{139, 0, 7258, 138}},
+	{Components: []int{139, 0, 7258, 68}},
+	{Components: []int{139, 0, 7258, 66}},
+	{Components: []int{139, 0, 7258, 52}},
+	{Components: []int{139, 0, 7258, 42}},
+	{Components: []int{139, 0, 7258, 31}},
+	{Components: []int{139, 0, 7258, 6}},
+	{Components: []int{139, 0, 7258, 5}},
+	{Components: []int{139, 0, 7258, 2}},
+	{Components: []int{139, 0, 7258, 0}},
+	{Components: []int{139, 0, 7257, 0}},
+	{Components: []int{139, 0, 7256, 0}},
+	{Components: []int{139, 0, 7255, 0}},
+	{Components: []int{139, 0, 7254, 0}},
+	{Components: []int{139, 0, 7253, 0}},
+	{Components: []int{139, 0, 7252, 0}},
+	{Components: []int{139, 0, 7251, 0}},
+	{Components: []int{139, 0, 7250, 0}},
+	{Components: []int{139, 0, 7249, 0}},
+	{Components: []int{139, 0, 7248, 0}},
+	{Components: []int{139, 0, 7247, 0}},
+	{Components: []int{139, 0, 7246, 0}},
+	{Components: []int{139, 0, 7245, 0}},
+	{Components: []int{139, 0, 7244, 0}},
+	{Components: []int{139, 0, 7243, 0}},
+	{Components: []int{139, 0, 7242, 0}},
+	{Components: []int{139, 0, 7241, 0}},
+	{Components: []int{139, 0, 7239, 0}},
+	{Components: []int{139, 0, 7238, 0}},
+	{Components: []int{139, 0, 7237, 0}},
+	{Components: []int{139, 0, 7236, 0}},
+	{Components: []int{139, 0, 7234, 0}},
+	{Components: []int{139, 0, 7233, 0}},
+	{Components: []int{139, 0, 7232, 3}},
+	{Components: []int{139, 0, 7232, 0}},
+	{Components: []int{139, 0, 7231, 0}},
+	{Components: []int{139, 0, 7230, 0}},
+	{Components: []int{139, 0, 7229, 0}},
+	{Components: []int{139, 0, 7228, 0}},
+	{Components: []int{139, 0, 7227, 0}},
+	{Components: []int{139, 0, 7226, 0}},
+	{Components: []int{139, 0, 7225, 0}},
+	{Components: []int{139, 0, 7224, 0}},
+	{Components: []int{139, 0, 7223, 0}},
+	{Components: []int{139, 0, 7222, 0}},
+	{Components: []int{139, 0, 7221, 0}},
+	{Components: []int{139, 0, 7220, 0}},
+	{Components: []int{139, 0, 7219, 3}},
+	{Components: []int{139, 0, 7219, 0}},
+	{Components: []int{139, 0, 7218, 0}},
+	{Components: []int{139, 0, 7217, 0}},
+	{Components: []int{139, 0, 7216, 0}},
+	{Components: []int{139, 0, 7215, 0}},
+	{Components: []int{139, 0, 7214, 0}},
+	{Components: []int{139, 0, 7213, 0}},
+	{Components: []int{139, 0, 7212, 0}},
+	{Components: []int{139, 0, 7211, 0}},
+	{Components: []int{139, 0, 7210, 0}},
+	{Components: []int{139, 0, 7208, 0}},
+	{Components: []int{139, 0, 7207, 2}},
+	{Components: []int{139, 0, 7207, 0}},
+	{Components: []int{139, 0, 7206, 2}},
+	{Components: []int{139, 0, 7205, 0}},
+	{Components: []int{138, 0, 7204, 183}},
+	{Components: []int{138, 0, 7204, 168}},
+	{Components: []int{138, 0, 7204, 157}},
+	{Components: []int{138, 0, 7204, 94}},
+	{Components: []int{138, 0, 7204, 92}},
+	{Components: []int{138, 0, 7204, 49}},
+	{Components: []int{138, 0, 7204, 35}},
+	{Components: []int{138, 0, 7204, 23}},
+	{Components: []int{138, 0, 7204, 15}},
+	{Components: []int{138, 0, 7204, 4}},
+	{Components: []int{138, 0, 7204, 2}},
+	{Components: []int{138, 0, 7204, 0}},
+	{Components: []int{138, 0, 7203, 0}},
+	{Components: []int{138, 0, 7201, 0}},
+	{Components: []int{138, 0, 7200, 0}},
+	{Components: []int{138, 0, 7199, 0}},
+	{Components: []int{138, 0, 7198, 0}},
+	{Components: []int{138, 0, 7197, 0}},
+	{Components: []int{138, 0, 7195, 0}},
+	{Components: []int{138, 0, 7194, 0}},
+	{Components: []int{138, 0, 7193, 0}},
+	{Components: []int{138, 0, 7191, 0}},
+	{Components: []int{138, 0, 7190, 0}},
+	{Components: []int{138, 0, 7189, 0}},
+	{Components: []int{138, 0, 7188, 0}},
+	{Components: []int{138, 0, 7187, 0}},
+	{Components: []int{138, 0, 7186, 0}},
+	{Components: []int{138, 0, 7185, 0}},
+	{Components: []int{138, 0, 7184, 0}},
+	{Components: []int{138, 0, 7183, 3}},
+	{Components: []int{138, 0, 7183, 0}},
+	{Components: []int{138, 0, 7182, 2}},
+	{Components: []int{138, 0, 7182, 0}},
+	{Components: []int{138, 0, 7181, 0}},
+	{Components: []int{138, 0, 7180, 2}},
+	{Components: []int{138, 0, 7180, 0}},
+	{Components: []int{138, 0, 7179, 0}},
+	{Components: []int{138, 0, 7178, 0}},
+	{Components: []int{138, 0, 7177, 0}},
+	{Components: []int{138, 0, 7176, 0}},
+	{Components: []int{138, 0, 7175, 0}},
+	{Components: []int{138, 0, 7174, 0}},
+	{Components: []int{138, 0, 7173, 0}},
+	{Components: []int{138, 0, 7172, 0}},
+	{Components: []int{138, 0, 7171, 0}},
+	{Components: []int{138, 0, 7170, 0}},
+	{Components: []int{138, 0, 7169, 0}},
+	{Components: []int{138, 0, 7168, 0}},
+	{Components: []int{138, 0, 7167, 0}},
+	{Components: []int{138, 0, 7166, 2}},
+	{Components: []int{138, 0, 7166, 0}},
+	{Components: []int{138, 0, 7165, 0}},
+	{Components: []int{138, 0, 7164, 0}},
+	{Components: []int{138, 0, 7163, 0}},
+	{Components: []int{138, 0, 7158, 0}},
+	{Components: []int{138, 0, 7157, 0}},
+	{Components: []int{138, 0, 7156, 0}},
+	{Components: []int{138, 0, 7155, 0}},
+	{Components: []int{138, 0, 7153, 0}},
+	{Components: []int{138, 0, 7152, 0}},
+	{Components: []int{137, 0, 7151, 119}},
+	{Components: []int{137, 0, 7151, 70}},
+	{Components: []int{137, 0, 7151, 69}},
+	{Components: []int{137, 0, 7151, 68}},
+	{Components: []int{137, 0, 7151, 55}},
+	{Components: []int{137, 0, 7151, 40}},
+	{Components: []int{137, 0, 7151, 32}},
+	{Components: []int{137, 0, 7151, 27}},
+	{Components: []int{137, 0, 7151, 15}},
+	{Components: []int{137, 0, 7151, 6}},
+	{Components: []int{137, 0, 7151, 5}},
+	{Components: []int{137, 0, 7151, 3}},
+	{Components: []int{137, 0, 7151, 0}},
+	{Components: []int{137, 0, 7150, 0}},
+	{Components: []int{137, 0, 7149, 0}},
+	{Components: []int{137, 0, 7148, 0}},
+	{Components: []int{137, 0, 7147, 0}},
+	{Components: []int{137, 0, 7146, 0}},
+	{Components: []int{137, 0, 7145, 0}},
+	{Components: []int{137, 0, 7144, 0}},
+	{Components: []int{137, 0, 7143, 0}},
+	{Components: []int{137, 0, 7142, 0}},
+	{Components: []int{137, 0, 7141, 3}},
+	{Components: []int{137, 0, 7139, 0}},
+	{Components: []int{137, 0, 7138, 0}},
+	{Components: []int{137, 0, 7137, 0}},
+	{Components: []int{137, 0, 7136, 0}},
+	{Components: []int{137, 0, 7135, 0}},
+	{Components: []int{137, 0, 7134, 0}},
+	{Components: []int{137, 0, 7133, 0}},
+	{Components: []int{137, 0, 7132, 0}},
+	{Components: []int{137, 0, 7131, 0}},
+	{Components: []int{137, 0, 7130, 0}},
+	{Components: []int{137, 0, 7128, 0}},
+	{Components: []int{137, 0, 7127, 2}},
+	{Components: []int{137, 0, 7127, 0}},
+	{Components: []int{137, 0, 7126, 0}},
+	{Components: []int{137, 0, 7123, 0}},
+	{Components: []int{137, 0, 7122, 0}},
+	{Components: []int{137, 0, 7121, 0}},
+	{Components: []int{137, 0, 7120, 0}},
+	{Components: []int{137, 0, 7119, 0}},
+	{Components: []int{137, 0, 7118, 2}},
+	{Components: []int{137, 0, 7118, 0}},
+	{Components: []int{137, 0, 7117, 2}},
+	{Components: []int{137, 0, 7117, 0}},
+	{Components: []int{137, 0, 7116, 0}},
+	{Components: []int{137, 0, 7115, 0}},
+	{Components: []int{137, 0, 7114, 0}},
+	{Components: []int{137, 0, 7113, 0}},
+	{Components: []int{137, 0, 7112, 0}},
+	{Components: []int{137, 0, 7111, 0}},
+	{Components: []int{137, 0, 7110, 0}},
+	{Components: []int{137, 0, 7109, 0}},
+	{Components: []int{137, 0, 7108, 0}},
+	{Components: []int{137, 0, 7107, 0}},
+	{Components: []int{137, 0, 7106, 2}},
+	{Components: []int{137, 0, 7106, 0}},
+	{Components: []int{137, 0, 7104, 0}},
+	{Components: []int{136, 0, 7103, 113}},
+	{Components: []int{136, 0, 7103, 94}},
+	{Components: []int{136, 0, 7103, 92}},
+	{Components: []int{136, 0, 7103, 49}},
+	{Components: []int{136, 0, 7103, 48}},
+	{Components: []int{136, 0, 7103, 33}},
+	{Components: []int{136, 0, 7103, 25}},
+	{Components: []int{136, 0, 7103, 17}},
+	{Components: []int{136, 0, 7103, 15}},
+	{Components: []int{136, 0, 7103, 3}},
+	{Components: []int{136, 0, 7103, 0}},
+	{Components: []int{136, 0, 7102, 0}},
+	{Components: []int{136, 0, 7101, 0}},
+	{Components: []int{136, 0, 7100, 0}},
+	{Components: []int{136, 0, 7099, 0}},
+	{Components: []int{136, 0, 7098, 0}},
+	{Components: []int{136, 0, 7097, 0}},
+	{Components: []int{136, 0, 7096, 0}},
+	{Components: []int{136, 0, 7095, 0}},
+	{Components: []int{136, 0, 7094, 0}},
+	{Components: []int{136, 0, 7093, 0}},
+	{Components: []int{136, 0, 7092, 0}},
+	{Components: []int{136, 0, 7091, 2}},
+	{Components: []int{136, 0, 7091, 0}},
+	{Components: []int{136, 0, 7090, 0}},
+	{Components: []int{136, 0, 7089, 0}},
+	{Components: []int{136, 0, 7088, 0}},
+	{Components: []int{136, 0, 7087, 0}},
+	{Components: []int{136, 0, 7086, 0}},
+	{Components: []int{136, 0, 7085, 0}},
+	{Components: []int{136, 0, 7084, 0}},
+	{Components: []int{136, 0, 7083, 0}},
+	{Components: []int{136, 0, 7082, 2}},
+	{Components: []int{136, 0, 7082, 0}},
+	{Components: []int{136, 0, 7081, 0}},
+	{Components: []int{136, 0, 7080, 0}},
+	{Components: []int{136, 0, 7079, 0}},
+	{Components: []int{136, 0, 7078, 0}},
+	{Components: []int{136, 0, 7077, 0}},
+	{Components: []int{136, 0, 7075, 0}},
+	{Components: []int{136, 0, 7074, 0}},
+	{Components: []int{136, 0, 7073, 0}},
+	{Components: []int{136, 0, 7072, 0}},
+	{Components: []int{136, 0, 7070, 0}},
+	{Components: []int{136, 0, 7069, 0}},
+	{Components: []int{136, 0, 7068, 0}},
+	{Components: []int{136, 0, 7067, 2}},
+	{Components: []int{136, 0, 7067, 0}},
+	{Components: []int{136, 0, 7066, 0}},
+	{Components: []int{136, 0, 7065, 0}},
+	{Components: []int{136, 0, 7064, 0}},
+	{Components: []int{136, 0, 7063, 0}},
+	{Components: []int{136, 0, 7062, 0}},
+	{Components: []int{136, 0, 7061, 0}},
+	{Components: []int{136, 0, 7060, 0}},
+	{Components: []int{136, 0, 7059, 0}},
+	{Components: []int{136, 0, 7058, 0}},
+	{Components: []int{136, 0, 7056, 0}},
+	{Components: []int{136, 0, 7055, 0}},
+	{Components: []int{136, 0, 7054, 0}},
+	{Components: []int{136, 0, 7053, 0}},
+	{Components: []int{136, 0, 7052, 2}},
+	{Components: []int{136, 0, 7052, 0}},
+	{Components: []int{136, 0, 7051, 0}},
+	{Components: []int{135, 0, 7049, 114}},
+	{Components: []int{135, 0, 7049, 97}},
+	{Components: []int{135, 0, 7049, 95}},
+	{Components: []int{135, 0, 7049, 84}},
+	{Components: []int{135, 0, 7049, 42}},
+	{Components: []int{135, 0, 7049, 41}},
+	{Components: []int{135, 0, 7049, 28}},
+	{Components: []int{135, 0, 7049, 17}},
+	{Components: []int{135, 0, 7049, 5}},
+	{Components: []int{135, 0, 7049, 3}},
+	{Components: []int{135, 0, 7049, 0}},
+	{Components: []int{135, 0, 7048, 0}},
+	{Components: []int{135, 0, 7047, 0}},
+	{Components: []int{135, 0, 7046, 0}},
+	{Components: []int{135, 0, 7045, 0}},
+	{Components: []int{135, 0, 7044, 0}},
+	{Components: []int{135, 0, 7043, 0}},
+	{Components: []int{135, 0, 7042, 0}},
+	{Components: []int{135, 0, 7041, 2}},
+	{Components: []int{135, 0, 7040, 0}},
+	{Components: []int{135, 0, 7039, 0}},
+	{Components: []int{135, 0, 7038, 0}},
+	{Components: []int{135, 0, 7037, 0}},
+	{Components: []int{135, 0, 7036, 0}},
+	{Components: []int{135, 0, 7035, 0}},
+	{Components: []int{135, 0, 7034, 0}},
+	{Components: []int{135, 0, 7033, 0}},
+	{Components: []int{135, 0, 7032, 0}},
+	{Components: []int{135, 0, 7031, 0}},
+	{Components: []int{135, 0, 7030, 0}},
+	{Components: []int{135, 0, 7029, 0}},
+	{Components: []int{135, 0, 7028, 0}},
+	{Components: []int{135, 0, 7026, 0}},
+	{Components: []int{135, 0, 7025, 0}},
+	{Components: []int{135, 0, 7024, 0}},
+	{Components: []int{135, 0, 7023, 0}},
+	{Components: []int{135, 0, 7022, 0}},
+	{Components: []int{135, 0, 7021, 0}},
+	{Components: []int{135, 0, 7020, 0}},
+	{Components: []int{135, 0, 7019, 0}},
+	{Components: []int{135, 0, 7018, 0}},
+	{Components: []int{135, 0, 7017, 0}},
+	{Components: []int{135, 0, 7016, 0}},
+	{Components: []int{135, 0, 7015, 0}},
+	{Components: []int{135, 0, 7014, 0}},
+	{Components: []int{135, 0, 7013, 2}},
+	{Components: []int{135, 0, 7013, 0}},
+	{Components: []int{135, 0, 7012, 4}},
+	{Components: []int{135, 0, 7012, 0}},
+	{Components: []int{135, 0, 7011, 0}},
+	{Components: []int{135, 0, 7010, 2}},
+	{Components: []int{135, 0, 7010, 0}},
+	{Components: []int{135, 0, 7009, 0}},
+	{Components: []int{135, 0, 7008, 0}},
+	{Components: []int{135, 0, 7007, 0}},
+	{Components: []int{135, 0, 7006, 0}},
+	{Components: []int{135, 0, 7005, 0}},
+	{Components: []int{135, 0, 7004, 0}},
+	{Components: []int{135, 0, 7003, 0}},
+	{Components: []int{135, 0, 7002, 0}},
+	{Components: []int{135, 0, 7000, 0}},
+	{Components: []int{135, 0, 6999, 2}},
+	{Components: []int{135, 0, 6999, 0}},
+	{Components: []int{134, 0, 6998, 165}},
+	{Components: []int{134, 0, 6998, 90}},
+	{Components: []int{134, 0, 6998, 88}},
+	{Components: []int{134, 0, 6998, 35}},
+	{Components: []int{134, 0, 6998, 23}},
+	{Components: []int{134, 0, 6998, 15}},
+	{Components: []int{134, 0, 6998, 5}},
+	{Components: []int{134, 0, 6998, 3}},
+	{Components: []int{134, 0, 6998, 2}},
+	{Components: []int{134, 0, 6998, 0}},
+	{Components: []int{134, 0, 6997, 0}},
+	{Components: []int{134, 0, 6996, 0}},
+	{Components: []int{134, 0, 6995, 0}},
+	{Components: []int{134, 0, 6994, 0}},
+	{Components: []int{134, 0, 6993, 0}},
+	{Components: []int{134, 0, 6992, 0}},
+	{Components: []int{134, 0, 6991, 0}},
+	{Components: []int{134, 0, 6990, 2}},
+	{Components: []int{134, 0, 6990, 0}},
+	{Components: []int{134, 0, 6989, 0}},
+	{Components: []int{134, 0, 6988, 2}},
+	{Components: []int{134, 0, 6988, 0}},
+	{Components: []int{134, 0, 6987, 0}},
+	{Components: []int{134, 0, 6985, 0}},
+	{Components: []int{134, 0, 6984, 0}},
+	{Components: []int{134, 0, 6983, 0}},
+	{Components: []int{134, 0, 6982, 0}},
+	{Components: []int{134, 0, 6981, 0}},
+	{Components: []int{134, 0, 6980, 0}},
+	{Components: []int{134, 0, 6979, 0}},
+	{Components: []int{134, 0, 6978, 0}},
+	{Components: []int{134, 0, 6977, 0}},
+	{Components: []int{134, 0, 6976, 0}},
+	{Components: []int{134, 0, 6975, 0}},
+	{Components: []int{134, 0, 6974, 3}},
+	{Components: []int{134, 0, 6974, 0}},
+	{Components: []int{134, 0, 6971, 2}},
+	{Components: []int{134, 0, 6970, 2}},
+	{Components: []int{134, 0, 6970, 0}},
+	{Components: []int{134, 0, 6968, 0}},
+	{Components: []int{134, 0, 6967, 0}},
+	{Components: []int{134, 0, 6966, 0}},
+	{Components: []int{134, 0, 6964, 0}},
+	{Components: []int{134, 0, 6963, 0}},
+	{Components: []int{134, 0, 6962, 0}},
+	{Components: []int{134, 0, 6961, 0}},
+	{Components: []int{134, 0, 6960, 0}},
+	{Components: []int{134, 0, 6958, 2}},
+	{Components: []int{134, 0, 6958, 0}},
+	{Components: []int{134, 0, 6957, 0}},
+	{Components: []int{134, 0, 6956, 0}},
+	{Components: []int{134, 0, 6955, 0}},
+	{Components: []int{134, 0, 6954, 0}},
+	{Components: []int{134, 0, 6953, 0}},
+	{Components: []int{134, 0, 6952, 0}},
+	{Components: []int{134, 0, 6950, 0}},
+	{Components: []int{134, 0, 6949, 0}},
+	{Components: []int{134, 0, 6948, 0}},
+	{Components: []int{134, 0, 6947, 0}},
+	{Components: []int{134, 0, 6946, 0}},
+	{Components: []int{134, 0, 6945, 2}},
+	{Components: []int{134, 0, 6945, 0}},
+	{Components: []int{134, 0, 6944, 2}},
+	{Components: []int{134, 0, 6944, 0}},
+	{Components: []int{133, 0, 6943, 141}},
+	{Components: []int{133, 0, 6943, 127}},
+	{Components: []int{133, 0, 6943, 126}},
+	{Components: []int{133, 0, 6943, 98}},
+	{Components: []int{133, 0, 6943, 53}},
+	{Components: []int{133, 0, 6943, 35}},
+	{Components: []int{133, 0, 6943, 27}},
+	{Components: []int{133, 0, 6943, 16}},
+	{Components: []int{133, 0, 6943, 6}},
+	{Components: []int{133, 0, 6943, 2}},
+	{Components: []int{133, 0, 6943, 0}},
+	{Components: []int{133, 0, 6942, 0}},
+	{Components: []int{133, 0, 6941, 0}},
+	{Components: []int{133, 0, 6940, 0}},
+	{Components: []int{133, 0, 6939, 0}},
+	{Components: []int{133, 0, 6938, 0}},
+	{Components: []int{133, 0, 6937, 0}},
+	{Components: []int{133, 0, 6936, 0}},
+	{Components: []int{133, 0, 6935, 0}},
+	{Components: []int{133, 0, 6933, 0}},
+	{Components: []int{133, 0, 6932, 0}},
+	{Components: []int{133, 0, 6931, 0}},
+	{Components: []int{133, 0, 6930, 0}},
+	{Components: []int{133, 0, 6929, 0}},
+	{Components: []int{133, 0, 6928, 0}},
+	{Components: []int{133, 0, 6927, 0}},
+	{Components: []int{133, 0, 6926, 0}},
+	{Components: []int{133, 0, 6925, 0}},
+	{Components: []int{133, 0, 6923, 0}},
+	{Components: []int{133, 0, 6922, 0}},
+	{Components: []int{133, 0, 6921, 0}},
+	{Components: []int{133, 0, 6920, 0}},
+	{Components: []int{133, 0, 6919, 0}},
+	{Components: []int{133, 0, 6918, 0}},
+	{Components: []int{133, 0, 6917, 0}},
+	{Components: []int{133, 0, 6916, 0}},
+	{Components: []int{133, 0, 6915, 0}},
+	{Components: []int{133, 0, 6914, 0}},
+	{Components: []int{133, 0, 6913, 0}},
+	{Components: []int{133, 0, 6912, 0}},
+	{Components: []int{133, 0, 6911, 0}},
+	{Components: []int{133, 0, 6909, 0}},
+	{Components: []int{133, 0, 6907, 0}},
+	{Components: []int{133, 0, 6906, 0}},
+	{Components: []int{133, 0, 6905, 0}},
+	{Components: []int{133, 0, 6904, 0}},
+	{Components: []int{133, 0, 6903, 0}},
+	{Components: []int{133, 0, 6902, 0}},
+	{Components: []int{133, 0, 6901, 0}},
+	{Components: []int{133, 0, 6900, 0}},
+	{Components: []int{133, 0, 6899, 0}},
+	{Components: []int{133, 0, 6898, 0}},
+	{Components: []int{133, 0, 6897, 0}},
+	{Components: []int{133, 0, 6896, 0}},
+	{Components: []int{133, 0, 6895, 0}},
+	{Components: []int{133, 0, 6893, 0}},
+	{Components: []int{133, 0, 6891, 0}},
+	{Components: []int{133, 0, 6888, 2}},
+	{Components: []int{133, 0, 6888, 0}},
+	{Components: []int{133, 0, 6887, 4}},
+	{Components: []int{133, 0, 6887, 0}},
+	{Components: []int{133, 0, 6886, 0}},
+	{Components: []int{133, 0, 6885, 0}},
+	{Components: []int{133, 0, 6884, 0}},
+	{Components: []int{133, 0, 6882, 0}},
+	{Components: []int{133, 0, 6881, 0}},
+	{Components: []int{133, 0, 6880, 0}},
+	{Components: []int{133, 0, 6879, 0}},
+	{Components: []int{133, 0, 6878, 0}},
+	{Components: []int{133, 0, 6877, 0}},
+	{Components: []int{133, 0, 6876, 4}},
+	{Components: []int{133, 0, 6876, 0}},
+	{Components: []int{133, 0, 6875, 0}},
+	{Components: []int{133, 0, 6874, 2}},
+	{Components: []int{133, 0, 6874, 0}},
+	{Components: []int{133, 0, 6873, 0}},
+	{Components: []int{133, 0, 6872, 0}},
+	{Components: []int{133, 0, 6871, 0}},
+	{Components: []int{133, 0, 6870, 0}},
+	{Components: []int{133, 0, 6869, 0}},
+	{Components: []int{133, 0, 6868, 0}},
+	{Components: []int{133, 0, 6866, 0}},
+	{Components: []int{133, 0, 6865, 0}},
+	{Components: []int{133, 0, 6864, 0}},
+	{Components: []int{133, 0, 6863, 0}},
+	{Components: []int{133, 0, 6862, 0}},
+	{Components: []int{133, 0, 6861, 0}},
+	{Components: []int{133, 0, 6860, 0}},
+	{Components: []int{133, 0, 6859, 0}},
+	{Components: []int{133, 0, 6858, 0}},
+	{Components: []int{133, 0, 6857, 0}},
+	{Components: []int{133, 0, 6856, 0}},
+	{Components: []int{133, 0, 6855, 0}},
+	{Components: []int{133, 0, 6854, 0}},
+	{Components: []int{133, 0, 6853, 0}},
+	{Components: []int{133, 0, 6852, 0}},
+	{Components: []int{133, 0, 6851, 0}},
+	{Components: []int{133, 0, 6850, 0}},
+	{Components: []int{133, 0, 6848, 0}},
+	{Components: []int{133, 0, 6847, 2}},
+	{Components: []int{133, 0, 6847, 0}},
+	{Components: []int{133, 0, 6844, 0}},
+	{Components: []int{133, 0, 6843, 0}},
+	{Components: []int{133, 0, 6842, 0}},
+	{Components: []int{133, 0, 6841, 0}},
+	{Components: []int{133, 0, 6840, 0}},
+	{Components: []int{133, 0, 6838, 0}},
+	{Components: []int{133, 0, 6837, 0}},
+	{Components: []int{133, 0, 6836, 0}},
+	{Components: []int{133, 0, 6835, 3}},
+	{Components: []int{133, 0, 6835, 0}},
+}
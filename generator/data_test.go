@@ -0,0 +1,70 @@
+package useragent
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The tests load YAML fixtures; the yamldata package cannot be imported
+// here without an import cycle.
+func init() {
+	RegisterYAMLDecoder(yaml.Unmarshal)
+}
+
+func TestCompiledData(t *testing.T) {
+	t.Run("MatchesYAML", func(t *testing.T) {
+		content, err := os.ReadFile("browsers.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := parseData(content)
+		if err != nil {
+			t.Fatalf("parseData failed: %v", err)
+		}
+		got, err := loadStore(Embedded(), false)
+		if err != nil {
+			t.Fatalf("loadStore failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(got.marketShare, want.marketShare) {
+			t.Errorf("Market share differs from browsers.yaml")
+		}
+		for browser, platforms := range want.data {
+			for osName, w := range platforms {
+				g, err := got.lookup(browser, osName)
+				if err != nil {
+					t.Fatalf("%s/%s missing from compiled data, run go generate", browser, osName)
+				}
+				if g.uaTemplate.source != w.uaTemplate.source || g.device != w.device {
+					t.Errorf("%s/%s: template or device differs from browsers.yaml", browser, osName)
+				}
+				if !reflect.DeepEqual(g.candidateTable(), w.candidateTable()) {
+					t.Errorf("%s/%s: versions differ from browsers.yaml, run go generate", browser, osName)
+				}
+				if !reflect.DeepEqual(g.denied, w.denied) {
+					t.Errorf("%s/%s: denylist differs from browsers.yaml", browser, osName)
+				}
+			}
+		}
+		if added, removed := diffStores(want, got); added != 0 || removed != 0 {
+			t.Errorf("Compiled data has %d extra and %d missing versions", added, removed)
+		}
+	})
+
+	t.Run("NoYAMLDecoder", func(t *testing.T) {
+		decoder := yamlDecoder
+		yamlDecoder = nil
+		defer func() { yamlDecoder = decoder }()
+
+		if _, err := NewWithSource(Reader(strings.NewReader(testData))); err == nil || !strings.Contains(err.Error(), "yamldata") {
+			t.Errorf("Expected error naming the yamldata package, got %v", err)
+		}
+		if _, err := New(); err != nil {
+			t.Errorf("New failed without a YAML decoder: %v", err)
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package useragent
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Platform statuses reported by DiffData.
+const (
+	StatusAdded   = "added"
+	StatusRemoved = "removed"
+	StatusChanged = "changed"
+)
+
+// DataDiff lists the differences between two datasets.
+type DataDiff struct {
+	Platforms []PlatformDiff `json:"platforms,omitempty"` // Sorted by browser and OS
+
+	DenylistAdded      []DenylistEntry `json:"denylist_added,omitempty"`
+	DenylistRemoved    []DenylistEntry `json:"denylist_removed,omitempty"`
+	MarketShareChanged bool            `json:"market_share_changed,omitempty"`
+}
+
+// PlatformDiff lists the differences of one browser/OS pair. Versions are
+// the platform's own; platforms with versions_from follow their source.
+type PlatformDiff struct {
+	Browser BrowserName `json:"browser"`
+	OS      OSName      `json:"os"`
+	Status  string      `json:"status"` // StatusAdded, StatusRemoved or StatusChanged
+
+	AddedVersions   []Version `json:"added_versions,omitempty"` // Newest first
+	RemovedVersions []Version `json:"removed_versions,omitempty"`
+
+	Template     *Change `json:"template,omitempty"`
+	Device       *Change `json:"device,omitempty"`
+	VersionsFrom *Change `json:"versions_from,omitempty"`
+
+	Metadata         []MetadataChange `json:"metadata,omitempty"` // Sorted by key and field
+	VariantsChanged  bool             `json:"variants_changed,omitempty"`
+	OverridesChanged bool             `json:"overrides_changed,omitempty"`
+}
+
+// Change is a value that differs, empty when unset.
+type Change struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// MetadataChange is a metadata value that differs for a version or prefix.
+type MetadataChange struct {
+	Key   string `json:"key"`   // Version or prefix
+	Field string `json:"field"` // released, eol, channel or engine
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// DenylistEntry is a denylist entry with its browser.
+type DenylistEntry struct {
+	Browser string `json:"browser"`
+	DeniedBuild
+}
+
+// Empty reports whether the datasets are the same.
+func (d *DataDiff) Empty() bool {
+	return len(d.Platforms) == 0 && len(d.DenylistAdded) == 0 && len(d.DenylistRemoved) == 0 && !d.MarketShareChanged
+}
+
+// DiffData compares two datasets, e.g. browsers.yaml before and after
+// running update-data.
+func DiffData(before, after *Config) (*DataDiff, error) {
+	d := &DataDiff{}
+
+	keys := make(map[[2]string]bool)
+	for _, c := range []*Config{before, after} {
+		for browser, platforms := range c.Browsers {
+			for os := range platforms {
+				keys[[2]string{browser, os}] = true
+			}
+		}
+	}
+	sorted := make([][2]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+		return sorted[i][1] < sorted[j][1]
+	})
+
+	for _, k := range sorted {
+		pd, err := diffPlatform(before, after, k[0], k[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", k[0], k[1], err)
+		}
+		if pd != nil {
+			d.Platforms = append(d.Platforms, *pd)
+		}
+	}
+
+	d.DenylistAdded = denylistMinus(after, before)
+	d.DenylistRemoved = denylistMinus(before, after)
+	d.MarketShareChanged = !reflect.DeepEqual(before.MarketShare, after.MarketShare) &&
+		(len(before.MarketShare) > 0 || len(after.MarketShare) > 0)
+	return d, nil
+}
+
+// diffPlatform compares one browser/OS pair; nil means no difference.
+func diffPlatform(before, after *Config, browser, os string) (*PlatformDiff, error) {
+	oldP, inOld := before.Browsers[browser][os]
+	newP, inNew := after.Browsers[browser][os]
+	pd := &PlatformDiff{Browser: BrowserName(browser), OS: OSName(os), Status: StatusChanged}
+	switch {
+	case !inOld:
+		pd.Status = StatusAdded
+	case !inNew:
+		pd.Status = StatusRemoved
+	}
+
+	var oldVersions, newVersions []Version
+	var err error
+	if inOld {
+		if oldVersions, err = before.versionsOf(browser, os); err != nil {
+			return nil, err
+		}
+	}
+	if inNew {
+		if newVersions, err = after.versionsOf(browser, os); err != nil {
+			return nil, err
+		}
+	}
+	pd.AddedVersions = versionsMinus(newVersions, oldVersions)
+	pd.RemovedVersions = versionsMinus(oldVersions, newVersions)
+
+	pd.Template = diffString(oldP.UATemplate, newP.UATemplate)
+	pd.Device = diffString(string(oldP.Device), string(newP.Device))
+	pd.VersionsFrom = diffString(oldP.VersionsFrom, newP.VersionsFrom)
+	pd.Metadata = diffMetadata(oldP.allMetadata(), newP.allMetadata())
+	pd.VariantsChanged = !reflect.DeepEqual(oldP.Variants, newP.Variants) && (len(oldP.Variants) > 0 || len(newP.Variants) > 0)
+	pd.OverridesChanged = !reflect.DeepEqual(oldP.Overrides, newP.Overrides) && (len(oldP.Overrides) > 0 || len(newP.Overrides) > 0)
+
+	if pd.Status == StatusChanged && len(pd.AddedVersions) == 0 && len(pd.RemovedVersions) == 0 &&
+		pd.Template == nil && pd.Device == nil && pd.VersionsFrom == nil && len(pd.Metadata) == 0 &&
+		!pd.VariantsChanged && !pd.OverridesChanged {
+		return nil, nil
+	}
+	return pd, nil
+}
+
+func diffString(before, after string) *Change {
+	if before == after {
+		return nil
+	}
+	return &Change{Old: before, New: after}
+}
+
+// versionsMinus returns the versions of a that are not in b, keeping order.
+func versionsMinus(a, b []Version) []Version {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v.String()] = true
+	}
+	var out []Version
+	for _, v := range a {
+		if !in[v.String()] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func diffMetadata(before, after map[string]VersionMeta) []MetadataChange {
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	var changes []MetadataChange
+	for _, key := range sortedKeys(keys) {
+		o, n := before[key], after[key]
+		fields := []struct{ name, before, after string }{
+			{"released", o.Released, n.Released},
+			{"eol", o.EOL, n.EOL},
+			{"channel", o.Channel, n.Channel},
+			{"engine", o.Engine, n.Engine},
+		}
+		for _, f := range fields {
+			if f.before != f.after {
+				changes = append(changes, MetadataChange{Key: key, Field: f.name, Old: f.before, New: f.after})
+			}
+		}
+	}
+	return changes
+}
+
+// denylistMinus returns the denylist entries of a that are not in b.
+func denylistMinus(a, b *Config) []DenylistEntry {
+	var out []DenylistEntry
+	for _, browser := range sortedKeys(a.Denylist) {
+		for _, entry := range a.Denylist[browser] {
+			found := false
+			for _, other := range b.Denylist[browser] {
+				if other == entry {
+					found = true
+					break
+				}
+			}
+			if !found {
+				out = append(out, DenylistEntry{Browser: browser, DeniedBuild: entry})
+			}
+		}
+	}
+	return out
+}
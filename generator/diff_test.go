@@ -0,0 +1,86 @@
+package useragent
+
+import "testing"
+
+func TestDiffData(t *testing.T) {
+	t.Run("Same", func(t *testing.T) {
+		config, err := Embedded().Load()
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		d, err := DiffData(config, config)
+		if err != nil {
+			t.Fatalf("DiffData failed: %v", err)
+		}
+		if !d.Empty() {
+			t.Errorf("Expected no differences, got %+v", d)
+		}
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		before := mustDecode(t, testData)
+		overlay, err := decodeOverlay([]byte(`
+browsers:
+    chrome:
+        windows:
+            ua_template: "Windows Chrome/{{version}}"
+            versions:
+                151:
+                    0:
+                        7510: [3]
+            metadata:
+                "151":
+                    released: "2026-01-13"
+        linux:
+            ua_template: "Linux Chrome/{{version}}"
+            versions_from: windows
+denylist:
+    chrome:
+        - version: "150.0.7500.12"
+`))
+		if err != nil {
+			t.Fatalf("decodeOverlay failed: %v", err)
+		}
+		after, err := Merge(before, overlay)
+		if err != nil {
+			t.Fatalf("Merge failed: %v", err)
+		}
+
+		d, err := DiffData(before, after)
+		if err != nil {
+			t.Fatalf("DiffData failed: %v", err)
+		}
+		if len(d.Platforms) != 2 {
+			t.Fatalf("Expected 2 changed platforms, got %+v", d.Platforms)
+		}
+		linux, windows := d.Platforms[0], d.Platforms[1]
+		if linux.OS != Linux || linux.Status != StatusAdded || linux.VersionsFrom == nil || linux.VersionsFrom.New != "windows" {
+			t.Errorf("Expected linux to be added, got %+v", linux)
+		}
+		if windows.Status != StatusChanged || len(windows.AddedVersions) != 1 || windows.AddedVersions[0].String() != "151.0.7510.3" {
+			t.Errorf("Expected 151.0.7510.3 to be added on windows, got %+v", windows)
+		}
+		if len(windows.RemovedVersions) != 0 {
+			t.Errorf("Expected no removed versions, got %v", windows.RemovedVersions)
+		}
+		if windows.Template == nil || windows.Template.Old != "Chrome/{{version}}" {
+			t.Errorf("Expected template change, got %+v", windows.Template)
+		}
+		want := MetadataChange{Key: "151", Field: "released", New: "2026-01-13"}
+		if len(windows.Metadata) != 1 || windows.Metadata[0] != want {
+			t.Errorf("Expected %+v, got %+v", want, windows.Metadata)
+		}
+		if len(d.DenylistAdded) != 1 || d.DenylistAdded[0].Version != "150.0.7500.12" {
+			t.Errorf("Expected a denylist entry to be added, got %+v", d.DenylistAdded)
+		}
+
+		reverse, err := DiffData(after, before)
+		if err != nil {
+			t.Fatalf("DiffData failed: %v", err)
+		}
+		if len(reverse.Platforms) != 2 || reverse.Platforms[0].Status != StatusRemoved ||
+			len(reverse.Platforms[1].RemovedVersions) != 1 || len(reverse.DenylistRemoved) != 1 {
+			t.Errorf("Expected the reverse changes, got %+v", reverse)
+		}
+	})
+}
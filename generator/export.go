@@ -0,0 +1,99 @@
+package useragent
+
+import (
+	"strings"
+)
+
+// CDPUserAgentOverride is the parameter object of the Chrome DevTools Protocol
+// Emulation.setUserAgentOverride (and Network.setUserAgentOverride) command.
+// It marshals to the exact JSON the protocol expects.
+type CDPUserAgentOverride struct {
+	UserAgent         string                `json:"userAgent"`
+	AcceptLanguage    string                `json:"acceptLanguage,omitempty"`
+	Platform          string                `json:"platform,omitempty"`
+	UserAgentMetadata *CDPUserAgentMetadata `json:"userAgentMetadata,omitempty"`
+}
+
+// CDPUserAgentMetadata mirrors the protocol's Emulation.UserAgentMetadata type.
+type CDPUserAgentMetadata struct {
+	Brands          []Brand  `json:"brands,omitempty"`
+	FullVersionList []Brand  `json:"fullVersionList,omitempty"`
+	FullVersion     string   `json:"fullVersion,omitempty"` // Deprecated in the protocol, still read by older Chrome
+	Platform        string   `json:"platform"`
+	PlatformVersion string   `json:"platformVersion"`
+	Architecture    string   `json:"architecture"`
+	Model           string   `json:"model"`
+	Mobile          bool     `json:"mobile"`
+	Bitness         string   `json:"bitness,omitempty"`
+	Wow64           bool     `json:"wow64,omitempty"`
+	FormFactors     []string `json:"formFactors,omitempty"`
+}
+
+// PlaywrightContextOptions holds the subset of Playwright's browser.newContext
+// options that carry the identity. Playwright has no way to pass UA metadata,
+// so the Client Hints headers are sent as extra headers to keep them consistent.
+type PlaywrightContextOptions struct {
+	UserAgent        string            `json:"userAgent"`
+	Locale           string            `json:"locale,omitempty"`
+	ExtraHTTPHeaders map[string]string `json:"extraHTTPHeaders,omitempty"`
+	IsMobile         bool              `json:"isMobile,omitempty"`
+	HasTouch         bool              `json:"hasTouch,omitempty"`
+}
+
+// CDPUserAgentOverride returns the Emulation.setUserAgentOverride parameters
+// for r. Metadata is omitted for browsers that do not implement Client Hints.
+func (r *Result) CDPUserAgentOverride() CDPUserAgentOverride {
+	override := CDPUserAgentOverride{
+		UserAgent:      r.UserAgent,
+		AcceptLanguage: r.Headers["Accept-Language"],
+		Platform:       platformProfiles[r.OS].navigator,
+	}
+	if len(r.Hints.Brands) == 0 {
+		return override
+	}
+
+	h := r.Hints
+	override.UserAgentMetadata = &CDPUserAgentMetadata{
+		Brands:          append([]Brand(nil), h.Brands...),
+		FullVersionList: append([]Brand(nil), h.FullVersionList...),
+		FullVersion:     r.Version.String(),
+		Platform:        h.Platform,
+		PlatformVersion: h.PlatformVersion,
+		Architecture:    h.Architecture,
+		Model:           h.Model,
+		Mobile:          h.Mobile,
+		Bitness:         h.Bitness,
+		Wow64:           h.Wow64,
+		FormFactors:     append([]string(nil), h.FormFactors...),
+	}
+	return override
+}
+
+// PlaywrightContextOptions returns the Playwright context options for r.
+func (r *Result) PlaywrightContextOptions() PlaywrightContextOptions {
+	opts := PlaywrightContextOptions{
+		UserAgent: r.UserAgent,
+		IsMobile:  r.Hints.Mobile,
+		HasTouch:  r.Hints.Mobile,
+	}
+	if lang := r.Headers["Accept-Language"]; lang != "" {
+		opts.Locale = primaryLanguage(lang)
+	}
+
+	for name, value := range r.Headers {
+		if name == "User-Agent" {
+			continue // Set through the userAgent option
+		}
+		if opts.ExtraHTTPHeaders == nil {
+			opts.ExtraHTTPHeaders = make(map[string]string)
+		}
+		opts.ExtraHTTPHeaders[strings.ToLower(name)] = value
+	}
+	return opts
+}
+
+// primaryLanguage returns the first tag of an Accept-Language value, e.g. "en-US" for "en-US,en;q=0.9".
+func primaryLanguage(acceptLanguage string) string {
+	tag := strings.SplitN(acceptLanguage, ",", 2)[0]
+	return strings.TrimSpace(strings.SplitN(tag, ";", 2)[0])
+}
@@ -4,32 +4,108 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
-	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Generator is the main entry point for generating user agents.
+// It is safe for concurrent use.
 type Generator struct {
-	store *dataStore
-	rng   *rand.Rand
+	store    atomic.Pointer[dataStore] // swapped by Reload
+	source   DataSource
+	strict   bool       // validate data with ValidateData
+	reloadMu sync.Mutex // serializes reloads
+
+	mu  sync.Mutex // guards rng
+	rng *rand.Rand
+}
+
+// GeneratorOption configures a Generator at construction.
+type GeneratorOption func(*generatorConfig)
+
+type generatorConfig struct {
+	source rand.Source
+	strict bool
+}
+
+// WithRandSource makes the Generator draw all of its randomness (version
+// choice, GREASE brands, pool rotation, identity seeds) from src. The same
+// source state and the same sequence of calls yield identical results.
+func WithRandSource(src rand.Source) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.source = src
+	}
 }
 
-// New creates a new Generator with loaded data.
-func New() (*Generator, error) {
-	store, err := loadData()
+// New creates a new Generator with the embedded data.
+// Without WithRandSource it is seeded from the current time.
+func New(opts ...GeneratorOption) (*Generator, error) {
+	return NewWithSource(Embedded(), opts...)
+}
+
+// NewWithSource creates a new Generator with data from src, e.g.
+// File("browsers.yaml") for a dataset newer than the embedded one.
+func NewWithSource(src DataSource, opts ...GeneratorOption) (*Generator, error) {
+	cfg := generatorConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.source == nil {
+		cfg.source = rand.NewSource(time.Now().UnixNano())
+	}
+
+	store, err := loadStore(src, cfg.strict)
 	if err != nil {
 		return nil, err
 	}
-	return &Generator{
-		store: store,
-		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
-	}, nil
+	g := &Generator{
+		source: src,
+		strict: cfg.strict,
+		rng:    rand.New(cfg.source),
+	}
+	g.store.Store(store)
+	return g, nil
+}
+
+// NewWithSeed creates a Generator whose output is reproducible: a given seed
+// and sequence of Generate calls always yield byte-identical results.
+func NewWithSeed(seed int64, opts ...GeneratorOption) (*Generator, error) {
+	return New(append([]GeneratorOption{WithRandSource(rand.NewSource(seed))}, opts...)...)
 }
 
 // Result contains the generated User-Agent string and headers.
 type Result struct {
 	UserAgent string
 	Headers   map[string]string
+	// Hints holds the Client Hints values behind the Sec-CH-UA-* headers.
+	Hints ClientHints
+
+	// Browser, OS, Device and Version identify the selected build.
+	Browser BrowserName
+	OS      OSName
+	Device  DeviceClass
+	Version Version
+	// Candidate is the full record the result was rendered from, including
+	// OS version, architecture and device model.
+	Candidate Candidate
+	// Profile is the automation profile the result was generated for.
+	Profile AutomationProfile
+}
+
+// clone returns a copy of r that shares no mutable state with it.
+func (r *Result) clone() *Result {
+	c := *r
+	c.Headers = make(map[string]string, len(r.Headers))
+	for k, v := range r.Headers {
+		c.Headers[k] = v
+	}
+	c.Hints.Brands = append([]Brand(nil), r.Hints.Brands...)
+	c.Hints.FullVersionList = append([]Brand(nil), r.Hints.FullVersionList...)
+	c.Hints.FormFactors = append([]string(nil), r.Hints.FormFactors...)
+	c.Version.Components = append([]int(nil), r.Version.Components...)
+	c.Candidate.Version.Components = append([]int(nil), r.Candidate.Version.Components...)
+	return &c
 }
 
 // Generate creates a new User-Agent and optional headers based on the provided options.
@@ -39,81 +115,213 @@ func (g *Generator) Generate(opts ...Option) (*Result, error) {
 		opt(options)
 	}
 
-	// 1. Get browser data
-	platforms, ok := g.store.data[options.browser]
-	if !ok {
-		return nil, fmt.Errorf("browser %s not found", options.browser)
+	// 1. Resolve browser/OS pairs and their matching versions
+	choices, err := g.platformChoices(options)
+	if err != nil {
+		return nil, err
 	}
-	bd, ok := platforms[options.os]
-	if !ok {
-		return nil, fmt.Errorf("os %s not found for browser %s", options.os, options.browser)
+	weights := make([]float64, len(choices))
+	for i, c := range choices {
+		weights[i] = c.weight
+	}
+	choice := choices[g.pickWeighted(weights)]
+
+	// 2. Select version, then the OS variant
+	i, err := g.selectVersion(choice.versions, options.strategy)
+	if err != nil {
+		return nil, err
 	}
+	candidate := g.pickCandidate(choice.candidates[i])
 
-	// 2. Filter versions
-	candidates := g.filterVersions(bd.versions, options)
-	if len(candidates) == 0 {
+	// 3. Build User-Agent string and headers
+	return g.render(choice.data, choice.selection(candidate, options), options), nil
+}
+
+// platformChoice is a browser/OS pair eligible for generation.
+type platformChoice struct {
+	browser    BrowserName
+	os         OSName
+	data       *browserData
+	weight     float64
+	versions   []Version     // Versions with candidates passing the filters, newest first
+	candidates [][]Candidate // Passing candidates of each version
+}
+
+func (c platformChoice) selection(cand Candidate, opts *generateOptions) selection {
+	return selection{
+		browser:   c.browser,
+		os:        c.os,
+		device:    c.data.device,
+		version:   cand.Version,
+		candidate: cand,
+		profile:   opts.profile,
+	}
+}
+
+// platformChoices resolves the options into the browser/OS pairs that have
+// versions matching the filters. Every returned choice has a positive weight.
+func (g *Generator) platformChoices(opts *generateOptions) ([]platformChoice, error) {
+	if opts.err != nil {
+		return nil, opts.err
+	}
+	store := g.data()
+	if opts.marketShare {
+		return g.marketShareChoices(store, opts)
+	}
+	if opts.browsers != nil || opts.oses != nil {
+		return g.matrixChoices(store, opts)
+	}
+
+	if err := checkAutomationProfile(opts.profile, opts.browser); err != nil {
+		return nil, err
+	}
+	bd, err := store.lookup(opts.browser, opts.os)
+	if err != nil {
+		return nil, err
+	}
+	versions, candidates, err := g.filterCandidates(bd, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
 		return nil, errors.New("no versions found matching criteria")
 	}
+	return []platformChoice{{browser: opts.browser, os: opts.os, data: bd, weight: 1, versions: versions, candidates: candidates}}, nil
+}
 
-	// 3. Select version
-	selectedVer := g.selectVersion(candidates, options.withWeight)
+// selection identifies everything needed to render a Result.
+type selection struct {
+	browser   BrowserName
+	os        OSName
+	device    DeviceClass
+	version   Version
+	candidate Candidate
+	grease    string // GREASE brand to use; picked at random when empty
+	profile   AutomationProfile
+}
 
-	// 4. Build User-Agent string
-	ua := strings.ReplaceAll(bd.uaTemplate, "{{version}}", selectedVer.String())
+// render builds the User-Agent string and headers for a selected version.
+func (g *Generator) render(bd *browserData, sel selection, opts *generateOptions) *Result {
+	rules := bd.rules(sel.version)
+	ua := rules.uaTemplate.execute(sel.candidate)
+	ua = applyAutomationUA(ua, sel.profile)
 
-	// 5. Build Headers
-	headers := g.generateHeaders(selectedVer, options)
+	if sel.grease == "" {
+		sel.grease = g.getGreaseBrand()
+	}
+	var hints ClientHints
+	if rules.clientHints {
+		hints = buildClientHints(sel)
+		applyAutomationHints(&hints, sel.profile)
+	}
+	headers := g.generateHeaders(hints, opts)
+	for name, value := range rules.headers {
+		if value == "" {
+			delete(headers, name)
+		} else {
+			headers[name] = value
+		}
+	}
 	headers["User-Agent"] = ua
+	if automationProfiles[sel.profile].noAcceptLanguage {
+		delete(headers, "Accept-Language")
+	}
 
+	// The selection shares its versions with the data, so callers get copies.
+	candidate := sel.candidate
+	candidate.Version = candidate.Version.clone()
 	return &Result{
 		UserAgent: ua,
 		Headers:   headers,
-	}, nil
+		Hints:     hints,
+		Browser:   sel.browser,
+		OS:        sel.os,
+		Device:    sel.device,
+		Version:   sel.version.clone(),
+		Candidate: candidate,
+		Profile:   sel.profile,
+	}
 }
 
-func (g *Generator) filterVersions(versions []Version, opts *generateOptions) []Version {
-	var filtered []Version
-	for _, v := range versions {
-		// Min version check
-		if len(opts.minVersion.Components) > 0 && v.Compare(opts.minVersion) < 0 {
-			continue
-		}
-		// Max version check
-		if len(opts.maxVersion.Components) > 0 && v.Compare(opts.maxVersion) > 0 {
-			continue
-		}
-		filtered = append(filtered, v)
+// selectVersion returns the index of the version picked by the strategy.
+func (g *Generator) selectVersion(versions []Version, strategy SelectionStrategy) (int, error) {
+	if len(versions) == 0 {
+		return -1, errors.New("no versions found matching criteria")
+	}
+	if strategy == nil {
+		strategy = Linear()
+	}
+
+	weights := strategy.Weights(versions)
+	if len(weights) != len(versions) {
+		return -1, fmt.Errorf("selection strategy returned %d weights for %d versions", len(weights), len(versions))
 	}
-	return filtered
+	i := g.pickWeighted(weights)
+	if i < 0 {
+		return -1, errors.New("selection strategy excluded every version")
+	}
+	return i, nil
 }
 
-func (g *Generator) selectVersion(versions []Version, weighted bool) Version {
-	if len(versions) == 0 {
-		return Version{} // Should not happen if filtered correctly
+// pickCandidate picks one of a version's candidates by variant weight.
+func (g *Generator) pickCandidate(candidates []Candidate) Candidate {
+	if len(candidates) == 1 {
+		return candidates[0]
 	}
-	if !weighted || len(versions) == 1 {
-		return versions[g.rng.Intn(len(versions))]
+	weights := make([]float64, len(candidates))
+	for i, c := range candidates {
+		weights[i] = c.weight
 	}
+	return candidates[g.pickWeighted(weights)]
+}
+
+// data returns the current data store. Callers should load it once per
+// operation so a concurrent reload cannot mix two datasets.
+func (g *Generator) data() *dataStore {
+	return g.store.Load()
+}
 
-	// Weighted random selection: newer versions have higher weight.
-	// Simple linear weight: index 0 (newest) gets weight N, index N-1 gets weight 1.
-	// Or maybe exponential? Let's stick to linear for now as requested "more new versions".
-	// Since versions are sorted descending (newest at 0), we give higher weight to lower indices.
+// pickWeighted returns an index chosen with probability proportional to its
+// weight, or -1 if no weight is positive.
+func (g *Generator) pickWeighted(weights []float64) int {
+	total := 0.0
+	last := -1
+	for i, w := range weights {
+		if w > 0 {
+			total += w
+			last = i
+		}
+	}
+	if last < 0 {
+		return -1
+	}
 
-	n := len(versions)
-	totalWeight := (n * (n + 1)) / 2 // Sum of 1..n
-	r := g.rng.Intn(totalWeight)
+	g.mu.Lock()
+	r := g.rng.Float64() * total
+	g.mu.Unlock()
 
-	// Find which item corresponds to r
-	// Weights: [n, n-1, ..., 1]
-	currentWeight := 0
-	for i, v := range versions {
-		weight := n - i
-		currentWeight += weight
-		if r < currentWeight {
-			return v
+	for i, w := range weights {
+		if w <= 0 {
+			continue
 		}
+		if r < w {
+			return i
+		}
+		r -= w
 	}
+	return last // Guards against floating point rounding
+}
+
+// intn returns a random number in [0, n) from the generator's source.
+func (g *Generator) intn(n int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rng.Intn(n)
+}
 
-	return versions[0] // Fallback
+// int63 returns a non-negative random int64 from the generator's source.
+func (g *Generator) int63() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rng.Int63()
 }
@@ -1,7 +1,12 @@
 package useragent
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGenerate(t *testing.T) {
@@ -101,4 +106,366 @@ func TestGenerate(t *testing.T) {
 			t.Errorf("Failed with '133.0.0.0': %v", err)
 		}
 	})
+
+	t.Run("Exporters", func(t *testing.T) {
+		res, err := g.Generate(WithClientHints(), WithAcceptLanguage("de-DE,de;q=0.9"))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		cdp := res.CDPUserAgentOverride()
+		if cdp.UserAgent != res.UserAgent || cdp.AcceptLanguage != "de-DE,de;q=0.9" || cdp.Platform != "Win32" {
+			t.Errorf("Unexpected CDP override: %+v", cdp)
+		}
+		if cdp.UserAgentMetadata == nil {
+			t.Fatal("Missing userAgentMetadata")
+		}
+		// The brands sent to the browser must match the header exactly, GREASE included.
+		if got := formatBrandList(cdp.UserAgentMetadata.Brands); got != res.Headers["Sec-CH-UA"] {
+			t.Errorf("CDP brands %q do not match Sec-CH-UA %q", got, res.Headers["Sec-CH-UA"])
+		}
+		data, err := json.Marshal(cdp)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if !strings.Contains(string(data), `"userAgentMetadata":{"brands":[{"brand":`) {
+			t.Errorf("Unexpected CDP JSON: %s", data)
+		}
+
+		pw := res.PlaywrightContextOptions()
+		if pw.Locale != "de-DE" {
+			t.Errorf("Expected locale de-DE, got %q", pw.Locale)
+		}
+		if pw.ExtraHTTPHeaders["sec-ch-ua"] != res.Headers["Sec-CH-UA"] {
+			t.Errorf("Missing sec-ch-ua in extra headers: %v", pw.ExtraHTTPHeaders)
+		}
+		if _, ok := pw.ExtraHTTPHeaders["user-agent"]; ok {
+			t.Error("User-Agent must be set through userAgent, not extra headers")
+		}
+
+		android, err := g.Generate(WithOS(Android))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if platform := android.CDPUserAgentOverride().Platform; platform != "Linux armv8l" {
+			t.Errorf("Expected navigator.platform %q on Android, got %q", "Linux armv8l", platform)
+		}
+	})
+
+	t.Run("AutomationProfiles", func(t *testing.T) {
+		profiles := []AutomationProfile{
+			ProfileHeadlessOld, ProfileHeadlessNew, ProfileChromeForTesting, ProfileWebDriver, ProfilePuppeteer,
+		}
+		for _, p := range profiles {
+			res, err := g.Generate(WithAutomationProfile(p), WithAllClientHints(), WithAcceptLanguage("en-US"))
+			if err != nil {
+				t.Fatalf("Generate(%s) failed: %v", p, err)
+			}
+			headless := strings.Contains(res.UserAgent, "HeadlessChrome/")
+			if headless != automationProfiles[p].headlessToken {
+				t.Errorf("%s: unexpected UA %s", p, res.UserAgent)
+			}
+			if strings.Contains(res.Headers["Sec-CH-UA"], "Google Chrome") != (p == ProfileWebDriver) {
+				t.Errorf("%s: unexpected brands %s", p, res.Headers["Sec-CH-UA"])
+			}
+			if findings := Validate(res.Headers); HasErrors(findings) {
+				t.Errorf("%s: headers have errors: %v", p, findings)
+			}
+		}
+
+		res, _ := g.Generate(WithAutomationProfile(ProfileHeadlessOld), WithAcceptLanguage("en-US"))
+		if _, ok := res.Headers["Accept-Language"]; ok {
+			t.Error("Old headless must not send Accept-Language")
+		}
+		if _, err := g.Generate(WithBrowser(Firefox), WithAutomationProfile(ProfileHeadlessNew)); err == nil {
+			t.Error("Expected error for automation profile on Firefox")
+		}
+	})
+
+	t.Run("MarketShare", func(t *testing.T) {
+		seen := make(map[OSName]bool)
+		for i := 0; i < 200; i++ {
+			res, err := g.Generate(WithMarketShare(), WithAllClientHints())
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			seen[res.OS] = true
+			if findings := Validate(res.Headers); HasErrors(findings) {
+				t.Fatalf("%s/%s headers have errors: %v", res.Browser, res.OS, findings)
+			}
+			if (res.OS == Android) != (res.Device == Mobile) {
+				t.Errorf("Unexpected device %s for %s", res.Device, res.OS)
+			}
+		}
+		if len(seen) < 2 {
+			t.Errorf("Expected a mix of operating systems, got %v", seen)
+		}
+
+		table := []MarketShare{
+			{Browser: Firefox, OS: Windows, Share: 0.9}, // no data, skipped
+			{Browser: Chrome, OS: Linux, Share: 0.1},
+		}
+		for i := 0; i < 10; i++ {
+			res, err := g.Generate(WithMarketShareTable(table))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if res.OS != Linux {
+				t.Fatalf("Expected linux, got %s", res.OS)
+			}
+		}
+
+		if _, err := g.Generate(WithMarketShareTable([]MarketShare{{Browser: Safari, OS: IOS, Share: 1}})); err == nil {
+			t.Error("Expected error for a table without available data")
+		}
+
+		// Selector errors are returned rather than skipping the entry
+		if _, err := g.Generate(WithMarketShare(), WithMinVersion("latest-99")); err == nil || !strings.Contains(err.Error(), "latest-99") {
+			t.Errorf("Expected the selector error, got %v", err)
+		}
+	})
+
+	t.Run("SelectionStrategies", func(t *testing.T) {
+		newest := g.data().data[Chrome][Windows].versions[0]
+		for i := 0; i < 10; i++ {
+			res, err := g.Generate(WithSelectionStrategy(LatestN(1)))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if res.Version.Compare(newest) != 0 {
+				t.Fatalf("LatestN(1) picked %s, newest is %s", res.Version, newest)
+			}
+
+			res, err = g.Generate(WithSelectionStrategy(ExplicitWeights(map[string]float64{"135": 1, "135.0.7049.42": 0})))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if res.Version.Components[0] != 135 || res.Version.String() == "135.0.7049.42" {
+				t.Fatalf("ExplicitWeights picked %s", res.Version)
+			}
+		}
+
+		versions := []Version{
+			{Components: []int{136, 0, 1}}, {Components: []int{136, 0, 0}},
+			{Components: []int{135, 0, 0}}, {Components: []int{134, 0, 0}},
+		}
+		weights := AdoptionCurve(0.6, 0.3).Weights(versions)
+		want := []float64{0.3, 0.3, 0.3, 0}
+		for i := range want {
+			if weights[i] != want[i] {
+				t.Fatalf("AdoptionCurve weights %v, want %v", weights, want)
+			}
+		}
+		weights = ExponentialDecay(0.5).Weights(versions)
+		if weights[0] != 1 || weights[3] != 0.125 {
+			t.Errorf("ExponentialDecay weights %v", weights)
+		}
+
+		if _, err := g.Generate(WithSelectionStrategy(ExplicitWeights(nil))); err == nil {
+			t.Error("Expected error when the strategy excludes every version")
+		}
+	})
+
+	t.Run("ReleaseDateFilters", func(t *testing.T) {
+		date := func(s string) time.Time {
+			d, _ := time.Parse(dateLayout, s)
+			return d
+		}
+		for i := 0; i < 20; i++ {
+			res, err := g.Generate(WithReferenceTime(date("2025-05-10")), WithMaxAge(30*24*time.Hour))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if major := res.Version.Components[0]; major != 136 {
+				t.Fatalf("Expected only 136 within 30 days of 2025-05-10, got %s", res.Version)
+			}
+
+			res, err = g.Generate(WithReleasedBetween(date("2025-04-01"), date("2025-04-30")))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if major := res.Version.Components[0]; major != 135 && major != 136 {
+				t.Fatalf("Expected 135 or 136, got %s", res.Version)
+			}
+		}
+
+		if _, err := g.Generate(WithReleasedBetween(date("2020-01-01"), date("2020-12-31"))); err == nil {
+			t.Error("Expected error for a range without releases")
+		}
+	})
+
+	t.Run("Seeded", func(t *testing.T) {
+		run := func() []*Result {
+			sg, err := NewWithSeed(42)
+			if err != nil {
+				t.Fatalf("NewWithSeed failed: %v", err)
+			}
+			var results []*Result
+			for i := 0; i < 20; i++ {
+				res, err := sg.Generate(WithMarketShare(), WithAllClientHints(), WithSelectionStrategy(Uniform()))
+				if err != nil {
+					t.Fatalf("Generate failed: %v", err)
+				}
+				results = append(results, res)
+			}
+			return results
+		}
+
+		first, second := run(), run()
+		for i := range first {
+			a, _ := json.Marshal(first[i])
+			b, _ := json.Marshal(second[i])
+			if string(a) != string(b) {
+				t.Fatalf("Result %d differs between runs:\n%s\n%s", i, a, b)
+			}
+		}
+	})
+	t.Run("ExcludeVersions", func(t *testing.T) {
+		for i := 0; i < 50; i++ {
+			res, err := g.Generate(WithMinVersion("133"), WithMaxVersion("134.999"), WithExcludeVersions("134", "133.0.6943.53"))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if v := res.Version.String(); res.Version.Components[0] == 134 || v == "133.0.6943.53" {
+				t.Fatalf("Excluded version %s generated", v)
+			}
+		}
+
+		if _, err := g.Generate(WithExcludeVersions("134.x")); err == nil {
+			t.Error("Expected error for malformed excluded version")
+		}
+	})
+
+	t.Run("Denylist", func(t *testing.T) {
+		store, err := parseData([]byte(`
+browsers:
+    chrome:
+        windows:
+            ua_template: "Chrome/{{version}}"
+            versions:
+                134:
+                    0:
+                        6998: [35, 88, 89]
+        linux:
+            ua_template: "Chrome/{{version}}"
+            versions_from: windows
+denylist:
+    chrome:
+        - version: 134.0.6998.88
+          reason: pulled
+        - version: 134.0.6998.35
+          os: linux
+`))
+		if err != nil {
+			t.Fatalf("parseData failed: %v", err)
+		}
+		dg := &Generator{rng: rand.New(rand.NewSource(1))}
+		dg.store.Store(store)
+
+		allowed := map[OSName]string{Windows: "35 89", Linux: "89"}
+		for os, want := range allowed {
+			for i := 0; i < 30; i++ {
+				res, err := dg.Generate(WithOS(os))
+				if err != nil {
+					t.Fatalf("Generate failed: %v", err)
+				}
+				if !strings.Contains(want, fmt.Sprint(res.Version.Components[3])) {
+					t.Fatalf("Denylisted version %s generated on %s", res.Version, os)
+				}
+			}
+		}
+
+		if _, err := parseData([]byte("browsers: {chrome: {}}\ndenylist: {chrome: [{version: 1.x}]}")); err == nil {
+			t.Error("Expected error for malformed denylist version")
+		}
+	})
+	t.Run("BrowserAndOSSets", func(t *testing.T) {
+		seen := make(map[OSName]bool)
+		for i := 0; i < 200; i++ {
+			// Safari and Edge have no data yet, so only Chrome pairs are possible.
+			res, err := g.Generate(WithBrowsers(Chrome, Safari, Edge), WithOSes(Windows, MacOS, IOS))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if res.Browser != Chrome || (res.OS != Windows && res.OS != MacOS) {
+				t.Fatalf("Unexpected combination %s/%s", res.Browser, res.OS)
+			}
+			seen[res.OS] = true
+		}
+		if !seen[Windows] || !seen[MacOS] {
+			t.Errorf("Expected both Windows and macOS, got %v", seen)
+		}
+
+		for i := 0; i < 50; i++ {
+			res, err := g.Generate(WithWeightedOSes(map[OSName]float64{Linux: 1, Android: 0}))
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if res.OS != Linux {
+				t.Fatalf("Zero-weight OS %s generated", res.OS)
+			}
+		}
+
+		// A later single-value option replaces the set
+		res, err := g.Generate(WithOSes(MacOS, Linux), WithOS(Android))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.OS != Android || res.Device != Mobile {
+			t.Errorf("Expected Android mobile, got %s %s", res.OS, res.Device)
+		}
+
+		if _, err := g.Generate(WithBrowsers(Safari), WithOSes(Windows)); err == nil {
+			t.Error("Expected error when no pair is possible")
+		}
+
+		// Selector errors are returned rather than skipping the pair
+		if _, err := g.Generate(WithOSes(Windows, Linux), WithMinVersion("latest-99")); err == nil || !strings.Contains(err.Error(), "latest-99") {
+			t.Errorf("Expected the selector error, got %v", err)
+		}
+	})
+
+	t.Run("ReturnedVersionsAreCopies", func(t *testing.T) {
+		opts := []Option{WithBrowser(Chrome), WithOS(Windows), WithSelectionStrategy(Uniform())}
+		snapshot := func(g *Generator) string {
+			versions, err := g.Versions(Chrome, Windows)
+			if err != nil {
+				t.Fatalf("Versions failed: %v", err)
+			}
+			return fmt.Sprint(versions)
+		}
+		want := snapshot(g)
+
+		for i := 0; i < 50; i++ {
+			res, err := g.Generate(opts...)
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			res.Version.Components[0] = -1
+			res.Candidate.Version.Components[1] = -1
+		}
+		candidates, err := g.Candidates(opts...)
+		if err != nil {
+			t.Fatalf("Candidates failed: %v", err)
+		}
+		for _, c := range candidates {
+			c.Version.Components[0] = -1
+		}
+		id := &Identity{Browser: Chrome, OS: Windows, Version: Version{Components: []int{1}}}
+		if _, err := g.Advance(id, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("Advance failed: %v", err)
+		}
+		id.Version.Components[0] = -1
+
+		fresh, err := New()
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if got := snapshot(g); got != want {
+			t.Errorf("Versions of g changed:\n%s\n%s", want, got)
+		}
+		if got := snapshot(fresh); got != want {
+			t.Errorf("Versions of a fresh generator changed:\n%s\n%s", want, got)
+		}
+	})
 }
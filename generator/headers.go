@@ -2,71 +2,173 @@ package useragent
 
 import (
 	"fmt"
-	"math/rand"
+	"strings"
 )
 
-// generateHeaders creates the map of HTTP headers based on options and selected version.
-func (g *Generator) generateHeaders(v Version, opts *generateOptions) map[string]string {
+// Brand is a single entry of a Sec-CH-UA style brand list.
+type Brand struct {
+	Brand   string `json:"brand"`
+	Version string `json:"version"`
+}
+
+// ClientHints holds the values behind the Sec-CH-UA-* headers. It is filled
+// for every Chromium-based result, whichever headers were requested, so
+// exporters can hand the complete metadata to a browser.
+type ClientHints struct {
+	Brands          []Brand
+	FullVersionList []Brand
+	Platform        string
+	PlatformVersion string
+	Architecture    string
+	Bitness         string
+	Model           string
+	Mobile          bool
+	Wow64           bool
+	FormFactors     []string
+}
+
+// platformProfile holds the Client Hints values that depend on the operating system.
+type platformProfile struct {
+	hint      string // Sec-CH-UA-Platform value
+	version   string // Sec-CH-UA-Platform-Version value
+	arch      string
+	bitness   string
+	navigator string // navigator.platform
+}
+
+var platformProfiles = map[OSName]platformProfile{
+	Windows:  {hint: "Windows", version: "10.0.0", arch: "x86", bitness: "64", navigator: "Win32"},
+	MacOS:    {hint: "macOS", version: "15.5.0", arch: "arm", bitness: "64", navigator: "MacIntel"},
+	Linux:    {hint: "Linux", version: "6.8.0", arch: "x86", bitness: "64", navigator: "Linux x86_64"},
+	Android:  {hint: "Android", version: "14.0.0", navigator: "Linux armv8l"},
+	ChromeOS: {hint: "Chrome OS", version: "16181.61.0", arch: "x86", bitness: "64", navigator: "Linux x86_64"},
+}
+
+// supportsClientHints reports whether the browser sends User-Agent Client Hints on the OS.
+// All iOS browsers run on WebKit, which does not.
+func supportsClientHints(browser BrowserName, os OSName) bool {
+	return vendorBrand(browser) != "" && os != IOS
+}
+
+// buildClientHints computes the hint values for a selected version.
+func buildClientHints(sel selection) ClientHints {
+	if !supportsClientHints(sel.browser, sel.os) {
+		return ClientHints{}
+	}
+	profile := platformProfiles[sel.os]
+	variant := sel.candidate
+
+	major := 0
+	if len(sel.version.Components) > 0 {
+		major = sel.version.Components[0]
+	}
+	vendor := vendorBrand(sel.browser)
+	fullVer := sel.version.String()
+
+	return ClientHints{
+		Brands: []Brand{
+			{Brand: sel.grease, Version: "99"},
+			{Brand: vendor, Version: fmt.Sprint(major)},
+			{Brand: "Chromium", Version: fmt.Sprint(major)},
+		},
+		FullVersionList: []Brand{
+			{Brand: sel.grease, Version: "99.0.0.0"},
+			{Brand: vendor, Version: fullVer},
+			{Brand: "Chromium", Version: fullVer},
+		},
+		Platform:        profile.hint,
+		PlatformVersion: variant.PlatformVersion,
+		Architecture:    variant.Architecture,
+		Bitness:         variant.Bitness,
+		Model:           variant.Model,
+		Mobile:          sel.device == Mobile,
+		FormFactors:     []string{variant.FormFactor},
+	}
+}
+
+// formFactor returns the Sec-CH-UA-Form-Factors value for a device class.
+func formFactor(d DeviceClass) string {
+	switch d {
+	case Mobile:
+		return "Mobile"
+	case Tablet:
+		return "Tablet"
+	default:
+		return "Desktop"
+	}
+}
+
+// generateHeaders creates the map of HTTP headers based on options and the computed hints.
+func (g *Generator) generateHeaders(hints ClientHints, opts *generateOptions) map[string]string {
 	headers := make(map[string]string)
+	if opts.acceptLanguage != "" {
+		headers["Accept-Language"] = opts.acceptLanguage
+	}
+	if len(hints.Brands) == 0 {
+		return headers
+	}
 
 	if opts.withSecCHUA {
-		headers["Sec-CH-UA"] = g.formatSecCHUA(v)
+		headers["Sec-CH-UA"] = formatBrandList(hints.Brands)
 	}
 	if opts.withSecCHUAMobile {
-		headers["Sec-CH-UA-Mobile"] = "?0" // Desktop only for now
+		headers["Sec-CH-UA-Mobile"] = formatBool(hints.Mobile)
 	}
 	if opts.withSecCHUAPlatform {
-		headers["Sec-CH-UA-Platform"] = "\"Windows\""
+		headers["Sec-CH-UA-Platform"] = quote(hints.Platform)
 	}
 	if opts.withSecCHUAFullVersion {
-		headers["Sec-CH-UA-Full-Version-List"] = g.formatSecCHUAFullVersion(v)
+		headers["Sec-CH-UA-Full-Version-List"] = formatBrandList(hints.FullVersionList)
 	}
 	if opts.withSecCHUAPlatformVer {
-		headers["Sec-CH-UA-Platform-Version"] = "\"10.0.0\""
+		headers["Sec-CH-UA-Platform-Version"] = quote(hints.PlatformVersion)
 	}
 	if opts.withSecCHUABitness {
-		headers["Sec-CH-UA-Bitness"] = "\"64\""
+		headers["Sec-CH-UA-Bitness"] = quote(hints.Bitness)
 	}
 	if opts.withSecCHUAArch {
-		headers["Sec-CH-UA-Arch"] = "\"x86\""
+		headers["Sec-CH-UA-Arch"] = quote(hints.Architecture)
 	}
 	if opts.withSecCHUAModel {
-		headers["Sec-CH-UA-Model"] = "\"\""
+		headers["Sec-CH-UA-Model"] = quote(hints.Model)
 	}
 	if opts.withSecCHUAWow64 {
-		headers["Sec-CH-UA-Wow64"] = "?0"
+		headers["Sec-CH-UA-Wow64"] = formatBool(hints.Wow64)
 	}
 	if opts.withSecCHUAFormFactors {
-		headers["Sec-CH-UA-Form-Factors"] = "\"Desktop\""
+		quoted := make([]string, len(hints.FormFactors))
+		for i, f := range hints.FormFactors {
+			quoted[i] = quote(f)
+		}
+		headers["Sec-CH-UA-Form-Factors"] = strings.Join(quoted, ", ")
 	}
 
 	return headers
 }
 
-func (g *Generator) formatSecCHUA(v Version) string {
-	grease := g.getGreaseBrand()
-	major := 0
-	if len(v.Components) > 0 {
-		major = v.Components[0]
+// formatBrandList renders brands as a structured header list, e.g. `"Chromium";v="133"`.
+func formatBrandList(brands []Brand) string {
+	parts := make([]string, len(brands))
+	for i, b := range brands {
+		parts[i] = fmt.Sprintf(`"%s";v="%s"`, b.Brand, b.Version)
 	}
-	return fmt.Sprintf(`"%s";v="99", "Not(A:Brand";v="99", "Google Chrome";v="%d", "Chromium";v="%d"`, grease, major, major)
+	return strings.Join(parts, ", ")
 }
 
-func (g *Generator) formatSecCHUAFullVersion(v Version) string {
-	grease := g.getGreaseBrand()
-	fullVer := v.String()
-
-	// Ensure full version has at least 4 components for Chrome if possible,
-	// but if the version is "145.2", we just output "145.2".
-	// However, spec usually expects full version.
-	// If it's Chrome, it should be 4 parts.
-	// We'll trust the data source or pad if needed?
-	// For now, just use v.String() which joins components.
+func formatBool(b bool) string {
+	if b {
+		return "?1"
+	}
+	return "?0"
+}
 
-	return fmt.Sprintf(`"%s";v="99.0.0.0", "Not(A:Brand";v="99.0.0.0", "Google Chrome";v="%s", "Chromium";v="%s"`, grease, fullVer, fullVer)
+func quote(s string) string {
+	return `"` + s + `"`
 }
 
+// greaseBrands lists the GREASE brand spellings a browser may send.
+var greaseBrands = []string{"Not(A:Brand", "Not?A_Brand", "Not A;Brand"}
+
 func (g *Generator) getGreaseBrand() string {
-	brands := []string{"Not(A:Brand", "Not?A_Brand", "Not A;Brand"}
-	return brands[rand.Intn(len(brands))]
+	return greaseBrands[g.intn(len(greaseBrands))]
 }
@@ -0,0 +1,208 @@
+package useragent
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Identity is a long-lived browser identity that can be persisted (it
+// marshals to JSON) and aged with Advance so it follows the browser's
+// release cadence instead of staying frozen on one build.
+type Identity struct {
+	Browser BrowserName `json:"browser"`
+	OS      OSName      `json:"os"`
+	Version Version     `json:"version"`
+	// OSVersion, Architecture and Model pin the platform variant.
+	OSVersion    string `json:"os_version,omitempty"`
+	Architecture string `json:"architecture,omitempty"`
+	Model        string `json:"model,omitempty"`
+	// Seed drives the identity's per-release update lag and GREASE brand,
+	// so aging and rendering are reproducible across restarts.
+	Seed    int64     `json:"seed"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// UpdateLag returns how long after a release an identity picks it up.
+type UpdateLag func(r *rand.Rand) time.Duration
+
+// DefaultUpdateLag models Chrome's staged rollout and restart behaviour:
+// most installs update within a few days, a minority trails by weeks.
+func DefaultUpdateLag(r *rand.Rand) time.Duration {
+	const day = 24 * time.Hour
+
+	lag := time.Duration(r.ExpFloat64() * float64(4*day))
+	if r.Float64() < 0.1 {
+		// Machines that are rarely restarted or sit behind update policies.
+		lag += 14*day + time.Duration(r.Int63n(int64(31*day)))
+	}
+	if lag > 90*day {
+		lag = 90 * day
+	}
+	return lag
+}
+
+// AgeOption configures Advance.
+type AgeOption func(*ageOptions)
+
+type ageOptions struct {
+	lag UpdateLag
+}
+
+// WithUpdateLag replaces DefaultUpdateLag with a custom lag distribution.
+func WithUpdateLag(lag UpdateLag) AgeOption {
+	return func(o *ageOptions) {
+		o.lag = lag
+	}
+}
+
+// NewIdentity creates an identity using the same browser, OS and version
+// selection as Generate. Created and Updated are set to the reference time
+// (see WithReferenceTime), so seeded generators create identical identities.
+func (g *Generator) NewIdentity(opts ...Option) (*Identity, error) {
+	res, err := g.Generate(opts...)
+	if err != nil {
+		return nil, err
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	now := options.referenceTime()
+	return &Identity{
+		Browser:      res.Browser,
+		OS:           res.OS,
+		Version:      res.Version,
+		OSVersion:    res.Candidate.OSVersion,
+		Architecture: res.Candidate.Architecture,
+		Model:        res.Candidate.Model,
+		Seed:         g.int63(),
+		Created:      now,
+		Updated:      now,
+	}, nil
+}
+
+// Advance moves id to the build it would have auto-updated to by at, given
+// the release dates in the data and the identity's update lag, and reports
+// whether the version changed. Versions never move backwards.
+//
+// Builds with their own release date are picked up individually. Where the
+// data only dates the major, as browsers.yaml does, the identity moves once
+// per major, to the oldest build of the newest major it has picked up: the
+// build that major first shipped with, not one released weeks later.
+func (g *Generator) Advance(id *Identity, at time.Time, opts ...AgeOption) (bool, error) {
+	if len(id.Version.Components) == 0 {
+		return false, fmt.Errorf("identity has no version")
+	}
+	options := ageOptions{lag: DefaultUpdateLag}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	bd, err := g.data().lookup(id.Browser, id.OS)
+	if err != nil {
+		return false, err
+	}
+
+	// Lags are drawn once per major release so repeated calls agree.
+	lags := make(map[int]time.Duration)
+	lagFor := func(major int) time.Duration {
+		if lag, ok := lags[major]; ok {
+			return lag
+		}
+		lag := options.lag(rand.New(rand.NewSource(id.Seed ^ int64(major))))
+		lags[major] = lag
+		return lag
+	}
+	// eligible reports whether the identity has picked up v by at, and
+	// whether v is only dated by its major.
+	eligible := func(v Version) (ok, majorOnly bool) {
+		if v.Compare(id.Version) <= 0 || bd.isDenied(v) {
+			return false, false
+		}
+		released, depth := bd.releaseDate(v)
+		if depth == 0 {
+			return false, false
+		}
+		// A date inherited from the major says nothing about when later
+		// builds of the identity's own major shipped.
+		if depth == 1 && v.Components[0] == id.Version.Components[0] {
+			return false, false
+		}
+		return !released.Add(lagFor(v.Components[0])).After(at), depth == 1
+	}
+
+	// Versions are sorted newest first, so the first eligible one wins.
+	for _, v := range bd.versions {
+		if v.Compare(id.Version) <= 0 {
+			break
+		}
+		ok, majorOnly := eligible(v)
+		if !ok {
+			continue
+		}
+		if majorOnly {
+			// The oldest eligible build of the same major
+			for i := len(bd.versions) - 1; i >= 0; i-- {
+				w := bd.versions[i]
+				if w.Components[0] != v.Components[0] {
+					continue
+				}
+				if ok, _ := eligible(w); ok {
+					v = w
+					break
+				}
+			}
+		}
+		id.Version = v.clone()
+		id.Updated = at
+		return true, nil
+	}
+	return false, nil
+}
+
+// Render regenerates the User-Agent and headers for id. Browser, OS and
+// version come from the identity; header options are taken from opts.
+func (g *Generator) Render(id *Identity, opts ...Option) (*Result, error) {
+	if len(id.Version.Components) == 0 {
+		return nil, fmt.Errorf("identity has no version")
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := checkAutomationProfile(options.profile, id.Browser); err != nil {
+		return nil, err
+	}
+	bd, err := g.data().lookup(id.Browser, id.OS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Browsers derive GREASE from the major version, so an identity keeps
+	// its brand until it updates to a new major.
+	r := rand.New(rand.NewSource(id.Seed ^ int64(id.Version.Components[0])))
+	return g.render(bd, selection{
+		browser:   id.Browser,
+		os:        id.OS,
+		device:    bd.device,
+		version:   id.Version,
+		candidate: bd.candidate(id.Browser, id.OS, id.Version, identityVariant(id, bd.variants)),
+		grease:    greaseBrands[r.Intn(len(greaseBrands))],
+		profile:   options.profile,
+	}, options), nil
+}
+
+// identityVariant returns the variant pinned by id. Identities created
+// before variants existed, or whose variant left the data, get one derived
+// from their seed.
+func identityVariant(id *Identity, variants []platformVariant) platformVariant {
+	for _, v := range variants {
+		if v.osVersion == id.OSVersion && v.arch == id.Architecture && v.model == id.Model {
+			return v
+		}
+	}
+	return variants[rand.New(rand.NewSource(id.Seed)).Intn(len(variants))]
+}
@@ -0,0 +1,132 @@
+package useragent
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestIdentity(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	week := func(*rand.Rand) time.Duration { return 7 * 24 * time.Hour }
+	date := func(s string) time.Time {
+		d, _ := time.Parse(dateLayout, s)
+		return d
+	}
+
+	id, err := g.NewIdentity(WithMaxVersion("133.0.6943.53"), WithMinVersion("133.0.6943.53"))
+	if err != nil {
+		t.Fatalf("NewIdentity failed: %v", err)
+	}
+
+	t.Run("Persist", func(t *testing.T) {
+		data, err := json.Marshal(id)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var restored Identity
+		if err := json.Unmarshal(data, &restored); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if restored.Version.String() != "133.0.6943.53" || restored.Seed != id.Seed {
+			t.Errorf("Round trip mismatch: %s", data)
+		}
+	})
+
+	t.Run("Advance", func(t *testing.T) {
+		changed, err := g.Advance(id, date("2025-03-08"), WithUpdateLag(week))
+		if err != nil {
+			t.Fatalf("Advance failed: %v", err)
+		}
+		if changed {
+			t.Errorf("Updated to %s before the lag elapsed", id.Version)
+		}
+
+		if changed, _ := g.Advance(id, date("2025-03-12"), WithUpdateLag(week)); !changed || id.Version.Components[0] != 134 {
+			t.Errorf("Expected update to 134, got %s", id.Version)
+		}
+		// Only majors are dated, so the identity takes 134's first build
+		// and stays on it until 135.
+		versions, _ := g.Versions(Chrome, Windows)
+		var first Version
+		for _, v := range versions {
+			if v.Components[0] == 134 {
+				first = v
+			}
+		}
+		if id.Version.Compare(first) != 0 {
+			t.Errorf("Expected the oldest 134 build %s, got %s", first, id.Version)
+		}
+		if changed, _ := g.Advance(id, date("2025-03-30"), WithUpdateLag(week)); changed {
+			t.Errorf("Moved within 134 to %s", id.Version)
+		}
+
+		if changed, _ := g.Advance(id, date("2025-05-20"), WithUpdateLag(week)); !changed || id.Version.Components[0] != 136 {
+			t.Errorf("Expected update to 136, got %s", id.Version)
+		}
+
+		before := id.Version
+		if changed, _ := g.Advance(id, date("2025-01-01"), WithUpdateLag(week)); changed || id.Version.Compare(before) != 0 {
+			t.Errorf("Version moved backwards to %s", id.Version)
+		}
+	})
+
+	t.Run("Render", func(t *testing.T) {
+		first, err := g.Render(id, WithAllClientHints())
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		second, _ := g.Render(id, WithAllClientHints())
+		if first.Headers["Sec-CH-UA"] != second.Headers["Sec-CH-UA"] {
+			t.Errorf("Rendering is not stable: %q != %q", first.Headers["Sec-CH-UA"], second.Headers["Sec-CH-UA"])
+		}
+		if first.Version.Compare(id.Version) != 0 {
+			t.Errorf("Rendered version %s, identity has %s", first.Version, id.Version)
+		}
+		if findings := Validate(first.Headers); HasErrors(findings) {
+			t.Errorf("Rendered headers have errors: %v", findings)
+		}
+	})
+	t.Run("Reproducible", func(t *testing.T) {
+		at := date("2025-06-01")
+		var ids [2]*Identity
+		for i := range ids {
+			seeded, err := NewWithSeed(3)
+			if err != nil {
+				t.Fatalf("NewWithSeed failed: %v", err)
+			}
+			if ids[i], err = seeded.NewIdentity(WithReferenceTime(at)); err != nil {
+				t.Fatalf("NewIdentity failed: %v", err)
+			}
+		}
+		if !ids[0].Created.Equal(at) || !ids[0].Updated.Equal(at) {
+			t.Errorf("Expected identity created at %v, got %v", at, ids[0].Created)
+		}
+		a, _ := json.Marshal(ids[0])
+		b, _ := json.Marshal(ids[1])
+		if string(a) != string(b) {
+			t.Errorf("Seeded identities differ:\n%s\n%s", a, b)
+		}
+	})
+
+	t.Run("Variant", func(t *testing.T) {
+		arm, err := g.NewIdentity(WithOS(MacOS), WithArchitecture("arm"), WithOSVersion("14"))
+		if err != nil {
+			t.Fatalf("NewIdentity failed: %v", err)
+		}
+		for i := 0; i < 10; i++ {
+			res, err := g.Render(arm, WithAllClientHints())
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			if res.Hints.Architecture != "arm" || res.Hints.PlatformVersion != "14.7.0" {
+				t.Fatalf("Render lost the identity's variant: %+v", res.Hints)
+			}
+		}
+	})
+}
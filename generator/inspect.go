@@ -0,0 +1,110 @@
+package useragent
+
+import (
+	"errors"
+	"sort"
+)
+
+// Platform summarizes one browser/OS pair of the dataset.
+type Platform struct {
+	Browser  BrowserName
+	OS       OSName
+	Device   DeviceClass
+	Versions int     // Number of versions that can be generated
+	Oldest   Version // Zero when Versions is 0
+	Latest   Version
+}
+
+// Browsers returns the browsers in the dataset, sorted by name.
+func (g *Generator) Browsers() []BrowserName {
+	store := g.data()
+	browsers := make([]BrowserName, 0, len(store.data))
+	for b := range store.data {
+		browsers = append(browsers, b)
+	}
+	sort.Slice(browsers, func(i, j int) bool { return browsers[i] < browsers[j] })
+	return browsers
+}
+
+// OperatingSystems returns the operating systems the dataset has for a
+// browser, sorted by name, or nil for an unknown browser.
+func (g *Generator) OperatingSystems(browser BrowserName) []OSName {
+	platforms := g.data().data[browser]
+	if platforms == nil {
+		return nil
+	}
+	oses := make([]OSName, 0, len(platforms))
+	for os := range platforms {
+		oses = append(oses, os)
+	}
+	sort.Slice(oses, func(i, j int) bool { return oses[i] < oses[j] })
+	return oses
+}
+
+// Versions returns the versions of a browser/OS pair that can be generated,
+// newest first. Denylisted versions are left out.
+func (g *Generator) Versions(browser BrowserName, os OSName) ([]Version, error) {
+	bd, err := g.data().lookup(browser, os)
+	if err != nil {
+		return nil, err
+	}
+	return bd.allowedVersions(), nil
+}
+
+// Latest returns the newest version of a browser/OS pair that can be generated.
+func (g *Generator) Latest(browser BrowserName, os OSName) (Version, error) {
+	versions, err := g.Versions(browser, os)
+	if err != nil {
+		return Version{}, err
+	}
+	if len(versions) == 0 {
+		return Version{}, errors.New("no versions found")
+	}
+	return versions[0], nil
+}
+
+// Template returns the User-Agent template of a browser/OS pair as written
+// in the data. Overrides may use other templates for some versions.
+func (g *Generator) Template(browser BrowserName, os OSName) (string, error) {
+	bd, err := g.data().lookup(browser, os)
+	if err != nil {
+		return "", err
+	}
+	return bd.uaTemplate.source, nil
+}
+
+// Matrix returns every browser/OS pair of the dataset, sorted by browser and
+// OS, so callers can tell which combinations exist without generating.
+func (g *Generator) Matrix() []Platform {
+	store := g.data()
+	var matrix []Platform
+	for browser, platforms := range store.data {
+		for os, bd := range platforms {
+			p := Platform{Browser: browser, OS: os, Device: bd.device}
+			if versions := bd.allowedVersions(); len(versions) > 0 {
+				p.Versions = len(versions)
+				p.Latest = versions[0]
+				p.Oldest = versions[len(versions)-1]
+			}
+			matrix = append(matrix, p)
+		}
+	}
+	sort.Slice(matrix, func(i, j int) bool {
+		if matrix[i].Browser != matrix[j].Browser {
+			return matrix[i].Browser < matrix[j].Browser
+		}
+		return matrix[i].OS < matrix[j].OS
+	})
+	return matrix
+}
+
+// allowedVersions returns a copy of the versions that are not denylisted.
+func (bd *browserData) allowedVersions() []Version {
+	versions := make([]Version, 0, len(bd.versions))
+	for _, v := range bd.versions {
+		if !bd.isDenied(v) {
+			versions = append(versions, v.clone())
+		}
+	}
+	return versions
+}
@@ -0,0 +1,68 @@
+package useragent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if got := g.Browsers(); !reflect.DeepEqual(got, []BrowserName{Chrome}) {
+		t.Errorf("Browsers() = %v", got)
+	}
+	if got, want := g.OperatingSystems(Chrome), []OSName{Android, Linux, MacOS, Windows}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OperatingSystems(chrome) = %v, want %v", got, want)
+	}
+	if got := g.OperatingSystems(Safari); got != nil {
+		t.Errorf("Expected nil for an unknown browser, got %v", got)
+	}
+
+	versions, err := g.Versions(Chrome, Windows)
+	if err != nil {
+		t.Fatalf("Versions failed: %v", err)
+	}
+	for i := 1; i < len(versions); i++ {
+		if versions[i].Compare(versions[i-1]) >= 0 {
+			t.Fatalf("Versions not newest first: %s before %s", versions[i-1], versions[i])
+		}
+	}
+	latest, err := g.Latest(Chrome, Windows)
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if latest.Compare(versions[0]) != 0 {
+		t.Errorf("Latest() = %s, want %s", latest, versions[0])
+	}
+	versions[0].Components[0] = 0
+	if again, _ := g.Latest(Chrome, Windows); again.Compare(latest) != 0 {
+		t.Errorf("Modifying the result of Versions changed the data")
+	}
+
+	tmpl, err := g.Template(Chrome, Android)
+	if err != nil {
+		t.Fatalf("Template failed: %v", err)
+	}
+	if tmpl != g.data().data[Chrome][Android].uaTemplate.source {
+		t.Errorf("Unexpected template %q", tmpl)
+	}
+	if _, err := g.Template(Chrome, IOS); err == nil {
+		t.Errorf("Expected error for an unknown pair")
+	}
+
+	matrix := g.Matrix()
+	if len(matrix) != 4 {
+		t.Fatalf("Expected 4 pairs, got %d", len(matrix))
+	}
+	for _, p := range matrix {
+		if p.Versions == 0 || p.Latest.Compare(latest) != 0 || p.Oldest.Compare(p.Latest) > 0 {
+			t.Errorf("Unexpected matrix entry %+v", p)
+		}
+		if p.OS == Android && p.Device != Mobile {
+			t.Errorf("Expected mobile Android, got %s", p.Device)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package useragent
+
+import (
+	"errors"
+	"fmt"
+)
+
+// marketShareChoices resolves the market-share table into platform choices.
+// Entries without data, with a mismatching device class, without versions
+// matching the filters or not supporting the automation profile are skipped;
+// a version selector that does not resolve is an error.
+func (g *Generator) marketShareChoices(store *dataStore, opts *generateOptions) ([]platformChoice, error) {
+	table := opts.marketShareTable
+	if table == nil {
+		table = store.marketShare
+	}
+
+	var choices []platformChoice
+	for _, ms := range table {
+		if ms.Share <= 0 || checkAutomationProfile(opts.profile, ms.Browser) != nil {
+			continue
+		}
+		bd, err := store.lookup(ms.Browser, ms.OS)
+		if err != nil {
+			continue
+		}
+		if ms.Device != "" && ms.Device != bd.device {
+			continue
+		}
+		versions, candidates, err := g.filterCandidates(bd, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", ms.Browser, ms.OS, err)
+		}
+		if len(versions) == 0 {
+			continue
+		}
+		choices = append(choices, platformChoice{
+			browser:    ms.Browser,
+			os:         ms.OS,
+			data:       bd,
+			weight:     ms.Share,
+			versions:   versions,
+			candidates: candidates,
+		})
+	}
+
+	if len(choices) == 0 {
+		return nil, errors.New("no market share entry matches the available data")
+	}
+	return choices, nil
+}
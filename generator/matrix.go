@@ -0,0 +1,70 @@
+package useragent
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// matrixChoices resolves browser and OS sets into platform choices. Pairs
+// without data, without versions matching the filters or not supporting the
+// automation profile are skipped; a version selector that does not resolve
+// is an error.
+func (g *Generator) matrixChoices(store *dataStore, opts *generateOptions) ([]platformChoice, error) {
+	browsers := opts.browsers
+	if browsers == nil {
+		browsers = map[BrowserName]float64{opts.browser: 1}
+	}
+	oses := opts.oses
+	if oses == nil {
+		oses = map[OSName]float64{opts.os: 1}
+	}
+
+	// Iterate in a fixed order so seeded generators stay reproducible.
+	browserNames := make([]BrowserName, 0, len(browsers))
+	for b := range browsers {
+		browserNames = append(browserNames, b)
+	}
+	sort.Slice(browserNames, func(i, j int) bool { return browserNames[i] < browserNames[j] })
+	osNames := make([]OSName, 0, len(oses))
+	for os := range oses {
+		osNames = append(osNames, os)
+	}
+	sort.Slice(osNames, func(i, j int) bool { return osNames[i] < osNames[j] })
+
+	var choices []platformChoice
+	for _, browser := range browserNames {
+		if browsers[browser] <= 0 || checkAutomationProfile(opts.profile, browser) != nil {
+			continue
+		}
+		for _, os := range osNames {
+			if oses[os] <= 0 {
+				continue
+			}
+			bd, err := store.lookup(browser, os)
+			if err != nil {
+				continue
+			}
+			versions, candidates, err := g.filterCandidates(bd, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %w", browser, os, err)
+			}
+			if len(versions) == 0 {
+				continue
+			}
+			choices = append(choices, platformChoice{
+				browser:    browser,
+				os:         os,
+				data:       bd,
+				weight:     browsers[browser] * oses[os],
+				versions:   versions,
+				candidates: candidates,
+			})
+		}
+	}
+
+	if len(choices) == 0 {
+		return nil, errors.New("no browser/OS combination has versions matching criteria")
+	}
+	return choices, nil
+}
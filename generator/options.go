@@ -1,19 +1,41 @@
 package useragent
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Option defines a function to configure the generation process.
 type Option func(*generateOptions)
 
 type generateOptions struct {
-	browser    BrowserName
-	os         OSName
-	minVersion Version
-	maxVersion Version
-	withWeight bool // If true, newer versions are more likely to be picked
+	browser     BrowserName
+	os          OSName
+	browsers    map[BrowserName]float64 // Weighted browser set, overrides browser
+	oses        map[OSName]float64      // Weighted OS set, overrides os
+	minVersion  Version
+	maxVersion  Version
+	minSelector string // Relative selector replacing minVersion, e.g. "latest-2"
+	maxSelector string
+	constraints []Constraint // All must hold
+	exclude     []Version    // Excluded versions and version prefixes
+	filters     []func(Candidate) bool
+	strategy    SelectionStrategy
+	uniqueness  Uniqueness // Only used by GenerateN
+
+	// Release date filters; zero values mean unbounded
+	maxAge        time.Duration
+	releasedAfter time.Time
+	releasedUntil time.Time
+	now           time.Time // reference time for maxAge and selectors, time.Now() when zero
+
+	marketShare      bool
+	marketShareTable []MarketShare // nil means the table from the data file
+
+	acceptLanguage string
+	profile        AutomationProfile
 
 	// Header options
 	withSecCHUA            bool
@@ -26,14 +48,16 @@ type generateOptions struct {
 	withSecCHUAFormFactors bool
 	withSecCHUAModel       bool
 	withSecCHUAWow64       bool
+
+	err error // Deferred option error, returned by Generate
 }
 
 // defaultOptions returns the default configuration.
 func defaultOptions() *generateOptions {
 	return &generateOptions{
-		browser:    Chrome,
-		os:         Windows,
-		withWeight: true, // Default to weighted selection
+		browser:  Chrome,
+		os:       Windows,
+		strategy: Linear(), // Default to weighted selection
 	}
 }
 
@@ -41,6 +65,7 @@ func defaultOptions() *generateOptions {
 func WithBrowser(b BrowserName) Option {
 	return func(o *generateOptions) {
 		o.browser = b
+		o.browsers = nil
 	}
 }
 
@@ -48,42 +73,200 @@ func WithBrowser(b BrowserName) Option {
 func WithOS(os OSName) Option {
 	return func(o *generateOptions) {
 		o.os = os
+		o.oses = nil
+	}
+}
+
+// WithBrowsers picks the browser among several, with equal weight. Combined
+// with the OS (or OS set) only pairs present in the data are generated;
+// impossible pairs such as Safari on Windows are skipped.
+func WithBrowsers(browsers ...BrowserName) Option {
+	weights := make(map[BrowserName]float64, len(browsers))
+	for _, b := range browsers {
+		weights[b] = 1
+	}
+	return WithWeightedBrowsers(weights)
+}
+
+// WithWeightedBrowsers is like WithBrowsers with relative weights per browser.
+// A browser/OS pair is weighted by the product of both weights.
+func WithWeightedBrowsers(weights map[BrowserName]float64) Option {
+	return func(o *generateOptions) {
+		o.browsers = weights
+	}
+}
+
+// WithOSes picks the operating system among several, with equal weight.
+// See WithBrowsers.
+func WithOSes(oses ...OSName) Option {
+	weights := make(map[OSName]float64, len(oses))
+	for _, os := range oses {
+		weights[os] = 1
+	}
+	return WithWeightedOSes(weights)
+}
+
+// WithWeightedOSes is like WithOSes with relative weights per OS.
+func WithWeightedOSes(weights map[OSName]float64) Option {
+	return func(o *generateOptions) {
+		o.oses = weights
+	}
+}
+
+// WithMarketShare picks browser, OS and device class from the market-share
+// table in the data file, weighted by share, so that many generated results
+// resemble real traffic. It takes precedence over WithBrowser, WithOS and
+// their multi-value variants;
+// entries without data are skipped.
+func WithMarketShare() Option {
+	return func(o *generateOptions) {
+		o.marketShare = true
+	}
+}
+
+// WithMarketShareTable is like WithMarketShare but uses the given table
+// instead of the one from the data file.
+func WithMarketShareTable(table []MarketShare) Option {
+	return func(o *generateOptions) {
+		o.marketShare = true
+		o.marketShareTable = table
 	}
 }
 
 // WithMinVersion sets the minimum allowed version.
-// Accepts string like "133.0.0.0" or "145.2".
+// Accepts string like "133.0.0.0" or "145.2", or a relative selector that is
+// resolved against the data at generation time: "latest", "latest-N" (N
+// majors behind the newest), "previous-major" or "oldest-supported".
 func WithMinVersion(v string) Option {
 	return func(o *generateOptions) {
+		o.minVersion, o.minSelector = Version{}, ""
+		if isSelector(v) {
+			o.minSelector = o.checkSelector(v)
+			return
+		}
 		o.minVersion = parseVersionString(v)
 	}
 }
 
-// WithMaxVersion sets the maximum allowed version.
+// WithMaxVersion sets the maximum allowed version. A relative selector (see
+// WithMinVersion) includes every build of the major version it resolves to.
 func WithMaxVersion(v string) Option {
 	return func(o *generateOptions) {
+		o.maxVersion, o.maxSelector = Version{}, ""
+		if isSelector(v) {
+			o.maxSelector = o.checkSelector(v)
+			return
+		}
 		o.maxVersion = parseVersionString(v)
 	}
 }
 
+// WithVersionConstraint only allows versions matching a constraint
+// expression such as ">=133 <136 || ^138" (see Constraint). Calling it more
+// than once requires every constraint to hold. A malformed expression makes
+// Generate return the parse error.
+func WithVersionConstraint(expr string) Option {
+	return func(o *generateOptions) {
+		c, err := ParseConstraint(expr)
+		if err != nil {
+			if o.err == nil {
+				o.err = err
+			}
+			return
+		}
+		o.constraints = append(o.constraints, c)
+	}
+}
+
+// WithConstraint is like WithVersionConstraint for an already parsed constraint.
+func WithConstraint(c Constraint) Option {
+	return func(o *generateOptions) {
+		o.constraints = append(o.constraints, c)
+	}
+}
+
+// WithExcludeVersions never generates the given versions. Each entry is a
+// full version ("135.0.7049.42") or a prefix ("135" excludes every 135.x
+// build). A malformed version makes Generate return an error.
+func WithExcludeVersions(versions ...string) Option {
+	return func(o *generateOptions) {
+		for _, s := range versions {
+			v, err := ParseVersion(s)
+			if err != nil {
+				if o.err == nil {
+					o.err = fmt.Errorf("excluded version: %w", err)
+				}
+				continue
+			}
+			o.exclude = append(o.exclude, v)
+		}
+	}
+}
+
+// WithMaxAge only allows versions released within d before the reference
+// time (see WithReferenceTime); versions released after it did not exist yet
+// and are excluded too, as are versions without a release date.
+func WithMaxAge(d time.Duration) Option {
+	return func(o *generateOptions) {
+		o.maxAge = d
+	}
+}
+
+// WithReleasedBetween only allows versions released in [from, to]. A zero
+// time leaves that end open. Versions without a release date are excluded.
+func WithReleasedBetween(from, to time.Time) Option {
+	return func(o *generateOptions) {
+		o.releasedAfter = from
+		o.releasedUntil = to
+	}
+}
+
+// WithReferenceTime sets the time that relative filters such as WithMaxAge
+// are evaluated against and NewIdentity stamps identities with (default: now).
+func WithReferenceTime(t time.Time) Option {
+	return func(o *generateOptions) {
+		o.now = t
+	}
+}
+
 // WithMinVersionStruct sets the minimum allowed version using a Version struct.
 func WithMinVersionStruct(v Version) Option {
 	return func(o *generateOptions) {
-		o.minVersion = v
+		o.minVersion, o.minSelector = v, ""
 	}
 }
 
 // WithMaxVersionStruct sets the maximum allowed version using a Version struct.
 func WithMaxVersionStruct(v Version) Option {
 	return func(o *generateOptions) {
-		o.maxVersion = v
+		o.maxVersion, o.maxSelector = v, ""
+	}
+}
+
+// WithSelectionStrategy sets how a version is picked among the candidates (default: Linear).
+func WithSelectionStrategy(s SelectionStrategy) Option {
+	return func(o *generateOptions) {
+		o.strategy = s
 	}
 }
 
 // WithWeightedSelection enables or disables weighted random selection (favoring newer versions).
+//
+// Deprecated: use WithSelectionStrategy with Linear or Uniform.
 func WithWeightedSelection(enable bool) Option {
 	return func(o *generateOptions) {
-		o.withWeight = enable
+		if enable {
+			o.strategy = Linear()
+		} else {
+			o.strategy = Uniform()
+		}
+	}
+}
+
+// WithAcceptLanguage sets the Accept-Language header, e.g. "en-US,en;q=0.9".
+func WithAcceptLanguage(lang string) Option {
+	return func(o *generateOptions) {
+		o.acceptLanguage = lang
 	}
 }
 
@@ -112,6 +295,70 @@ func WithAllClientHints() Option {
 	}
 }
 
+// referenceTime returns the time relative filters are evaluated against.
+func (o *generateOptions) referenceTime() time.Time {
+	if o.now.IsZero() {
+		return time.Now()
+	}
+	return o.now
+}
+
+// checkSelector returns s if it is a valid selector and records the error otherwise.
+func (o *generateOptions) checkSelector(s string) string {
+	if err := checkSelector(s); err != nil {
+		if o.err == nil {
+			o.err = err
+		}
+		return ""
+	}
+	return s
+}
+
+// resolveConstraints returns the version constraints with relative selectors,
+// including those given to WithMinVersion and WithMaxVersion, resolved
+// against bd.
+func (o *generateOptions) resolveConstraints(bd *browserData) ([]Constraint, error) {
+	all := o.constraints
+	if o.minSelector != "" {
+		all = append(all[:len(all):len(all)], Constraint{expr: ">=" + o.minSelector, alts: [][]term{{{op: ">=", selector: o.minSelector}}}})
+	}
+	if o.maxSelector != "" {
+		all = append(all[:len(all):len(all)], Constraint{expr: "<=" + o.maxSelector, alts: [][]term{{{op: "<=", selector: o.maxSelector}}}})
+	}
+
+	resolved := make([]Constraint, len(all))
+	now := o.referenceTime()
+	for i, c := range all {
+		r, err := c.resolve(bd, now)
+		if err != nil {
+			return nil, err
+		}
+		resolved[i] = r
+	}
+	return resolved, nil
+}
+
+func (o *generateOptions) hasDateFilter() bool {
+	return o.maxAge > 0 || !o.releasedAfter.IsZero() || !o.releasedUntil.IsZero()
+}
+
+// releasedInRange reports whether a release date passes the date filters.
+func (o *generateOptions) releasedInRange(released time.Time) bool {
+	if o.maxAge > 0 {
+		now := o.referenceTime()
+		if released.Before(now.Add(-o.maxAge)) || released.After(now) {
+			return false
+		}
+	}
+	if !o.releasedAfter.IsZero() && released.Before(o.releasedAfter) {
+		return false
+	}
+	if !o.releasedUntil.IsZero() && released.After(o.releasedUntil) {
+		return false
+	}
+	return true
+}
+
 func parseVersionString(s string) Version {
 	parts := strings.Split(s, ".")
 	var components []int
@@ -0,0 +1,402 @@
+package useragent
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Overlay changes a dataset without editing it, e.g. to add private
+// entries or drop versions from the embedded data. It is written like
+// browsers.yaml with two extra sections and applied by Merge in this order:
+//
+//	delete:          # removes a browser, a platform or a version subtree
+//	    - browser: chrome
+//	      os: android          # optional, default every OS of the browser
+//	      versions: "135"      # optional version or prefix, default the whole entry
+//	replace:         # replaces platforms wholesale
+//	    chrome:
+//	        linux: {...}
+//	browsers:        # merged into existing platforms or added
+//	    chrome:
+//	        windows:
+//	            versions: {...}
+//
+// Merged platforms gain the overlay's versions and metadata keys, take its
+// template, device, versions_from and variants when set, and append its
+// overrides. A market_share table replaces the base table; denylist entries
+// are appended. Deleting versions on one OS of a platform with versions_from
+// gives that platform its own copy of the shared versions.
+type Overlay struct {
+	SchemaVersion int                                  `yaml:"schema_version,omitempty" json:"schema_version,omitempty"`
+	Delete        []Deletion                           `yaml:"delete,omitempty" json:"delete,omitempty"`
+	Replace       map[string]map[string]PlatformConfig `yaml:"replace,omitempty" json:"replace,omitempty"`
+	Browsers      map[string]map[string]PlatformConfig `yaml:"browsers,omitempty" json:"browsers,omitempty"`
+	MarketShare   []MarketShare                        `yaml:"market_share,omitempty" json:"market_share,omitempty"`
+	Denylist      map[string][]DeniedBuild             `yaml:"denylist,omitempty" json:"denylist,omitempty"`
+}
+
+// Deletion is an entry of an overlay's delete section.
+type Deletion struct {
+	Browser  string `yaml:"browser" json:"browser"`
+	OS       string `yaml:"os,omitempty" json:"os,omitempty"`             // Empty applies to every OS
+	Versions string `yaml:"versions,omitempty" json:"versions,omitempty"` // Version or prefix; empty deletes the entry
+}
+
+// Layered loads base and applies the overlay files to it in order on every
+// load, so reloading picks up changes to any of them.
+func Layered(base DataSource, overlays ...string) DataSource {
+	return DataSourceFunc(func() (*Config, error) {
+		config, err := base.Load()
+		if err != nil {
+			return nil, err
+		}
+		layers := make([]*Overlay, len(overlays))
+		for i, path := range overlays {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if layers[i], err = decodeOverlay(content); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+		return Merge(config, layers...)
+	})
+}
+
+// decodeOverlay decodes an overlay written as YAML or JSON.
+func decodeOverlay(content []byte) (*Overlay, error) {
+	var overlay Overlay
+	if err := decodeData(content, &overlay); err != nil {
+		return nil, err
+	}
+	return &overlay, nil
+}
+
+// Merge returns base with the overlays applied in order. Neither base nor
+// the overlays are modified.
+func Merge(base *Config, overlays ...*Overlay) (*Config, error) {
+	merged := copyConfig(base)
+	for i, o := range overlays {
+		if err := merged.apply(o); err != nil {
+			return nil, fmt.Errorf("overlay %d: %w", i, err)
+		}
+	}
+	return merged, nil
+}
+
+// copyConfig copies the maps of c that apply modifies. Slices and platform
+// maps are replaced rather than modified, so they are shared.
+func copyConfig(c *Config) *Config {
+	out := &Config{
+		SchemaVersion: c.SchemaVersion,
+		Browsers:      make(map[string]map[string]PlatformConfig, len(c.Browsers)),
+		MarketShare:   c.MarketShare,
+		Denylist:      make(map[string][]DeniedBuild, len(c.Denylist)),
+		compiled:      make(map[string][]Version, len(c.compiled)),
+		decodeErrs:    c.decodeErrs,
+	}
+	for browser, platforms := range c.Browsers {
+		out.Browsers[browser] = make(map[string]PlatformConfig, len(platforms))
+		for osName, p := range platforms {
+			out.Browsers[browser][osName] = p
+		}
+	}
+	for browser, entries := range c.Denylist {
+		out.Denylist[browser] = entries
+	}
+	for key, versions := range c.compiled {
+		out.compiled[key] = versions
+	}
+	return out
+}
+
+func (c *Config) apply(o *Overlay) error {
+	if o.SchemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("schema version %d is newer than the supported version %d", o.SchemaVersion, CurrentSchemaVersion)
+	}
+
+	for _, d := range o.Delete {
+		if err := c.delete(d); err != nil {
+			return err
+		}
+	}
+
+	for browser, platforms := range o.Replace {
+		for osName, p := range platforms {
+			c.setPlatform(browser, osName, p)
+		}
+	}
+
+	for browser, platforms := range o.Browsers {
+		for osName, p := range platforms {
+			base, ok := c.Browsers[browser][osName]
+			if !ok {
+				c.setPlatform(browser, osName, p)
+				continue
+			}
+			merged, err := c.mergePlatform(browser, osName, base, p)
+			if err != nil {
+				return fmt.Errorf("%s/%s: %w", browser, osName, err)
+			}
+			c.setPlatform(browser, osName, merged)
+		}
+	}
+
+	if len(o.MarketShare) > 0 {
+		c.MarketShare = o.MarketShare
+	}
+	for browser, entries := range o.Denylist {
+		c.Denylist[browser] = append(c.Denylist[browser][:len(c.Denylist[browser]):len(c.Denylist[browser])], entries...)
+	}
+	return nil
+}
+
+// setPlatform stores p, whose versions are described by its own fields.
+func (c *Config) setPlatform(browser, osName string, p PlatformConfig) {
+	if c.Browsers[browser] == nil {
+		c.Browsers[browser] = make(map[string]PlatformConfig)
+	}
+	c.Browsers[browser][osName] = p
+	delete(c.compiled, browser+"/"+osName)
+}
+
+func (c *Config) delete(d Deletion) error {
+	platforms, ok := c.Browsers[d.Browser]
+	if !ok {
+		return fmt.Errorf("delete: unknown browser %q", d.Browser)
+	}
+	if d.OS != "" {
+		if _, ok := platforms[d.OS]; !ok {
+			return fmt.Errorf("delete: unknown os %q for browser %s", d.OS, d.Browser)
+		}
+	}
+
+	if d.Versions == "" {
+		if d.OS == "" {
+			delete(c.Browsers, d.Browser)
+			delete(c.Denylist, d.Browser)
+			for osName := range platforms {
+				delete(c.compiled, d.Browser+"/"+osName)
+			}
+			return nil
+		}
+		delete(platforms, d.OS)
+		delete(c.compiled, d.Browser+"/"+d.OS)
+		// Denylist entries limited to the deleted platform go with it
+		var kept []DeniedBuild
+		for _, entry := range c.Denylist[d.Browser] {
+			if string(entry.OS) != d.OS {
+				kept = append(kept, entry)
+			}
+		}
+		c.Denylist[d.Browser] = kept
+		return nil
+	}
+
+	prefix, err := ParseVersion(d.Versions)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	for osName, p := range platforms {
+		if d.OS != "" && osName != d.OS {
+			continue
+		}
+		// A delete limited to a platform that shares another platform's
+		// versions must not reach the source, so the platform takes a copy
+		// of them first.
+		if d.OS != "" && p.VersionsFrom != "" {
+			var err error
+			if p, err = c.inherit(d.Browser, osName, p); err != nil {
+				return fmt.Errorf("%s/%s: %w", d.Browser, osName, err)
+			}
+		}
+		versions, err := c.versionsOf(d.Browser, osName)
+		if err != nil {
+			return fmt.Errorf("%s/%s: %w", d.Browser, osName, err)
+		}
+		var kept []Version
+		for _, v := range versions {
+			if !hasPrefix(v, prefix) {
+				kept = append(kept, v)
+			}
+		}
+		metadata := make(map[string]VersionMeta)
+		for key, meta := range p.allMetadata() {
+			if v, err := ParseVersion(key); err != nil || !hasPrefix(v, prefix) {
+				metadata[key] = meta
+			}
+		}
+		p.setVersions(kept, metadata)
+		c.setPlatform(d.Browser, osName, p)
+	}
+	return nil
+}
+
+// inherit stores and returns p with the versions and metadata of its
+// versions_from platform added to its own and versions_from cleared.
+func (c *Config) inherit(browser, osName string, p PlatformConfig) (PlatformConfig, error) {
+	source, ok := c.Browsers[browser][p.VersionsFrom]
+	if !ok || p.VersionsFrom == osName {
+		return PlatformConfig{}, fmt.Errorf("versions_from references unknown platform %q", p.VersionsFrom)
+	}
+	own, err := c.versionsOf(browser, osName)
+	if err != nil {
+		return PlatformConfig{}, err
+	}
+	shared, err := c.versionsOf(browser, p.VersionsFrom)
+	if err != nil {
+		return PlatformConfig{}, err
+	}
+	metadata := source.allMetadata()
+	for key, meta := range p.allMetadata() {
+		metadata[key] = meta
+	}
+	versions := append(append([]Version(nil), own...), shared...)
+	p.setVersions(sortVersions(dedupeVersions(versions)), metadata)
+	p.VersionsFrom = ""
+	c.setPlatform(browser, osName, p)
+	return p, nil
+}
+
+// mergePlatform merges an overlay platform into a base platform.
+func (c *Config) mergePlatform(browser, osName string, base, p PlatformConfig) (PlatformConfig, error) {
+	if p.UATemplate != "" {
+		base.UATemplate = p.UATemplate
+	}
+	if p.Device != "" {
+		base.Device = p.Device
+	}
+	if p.VersionsFrom != "" {
+		base.VersionsFrom = p.VersionsFrom
+	}
+	if len(p.Variants) > 0 {
+		base.Variants = p.Variants
+	}
+	if len(p.Overrides) > 0 {
+		base.Overrides = append(base.Overrides[:len(base.Overrides):len(base.Overrides)], p.Overrides...)
+	}
+
+	versions, err := c.versionsOf(browser, osName)
+	if err != nil {
+		return PlatformConfig{}, err
+	}
+	added, err := p.AllVersions()
+	if err != nil {
+		return PlatformConfig{}, err
+	}
+	metadata := base.allMetadata()
+	for key, meta := range p.allMetadata() {
+		metadata[key] = meta
+	}
+	base.setVersions(sortVersions(dedupeVersions(append(append([]Version(nil), versions...), added...))), metadata)
+	return base, nil
+}
+
+// versionsOf returns the versions a platform defines itself, newest first.
+func (c *Config) versionsOf(browser, osName string) ([]Version, error) {
+	if compiled, ok := c.compiled[browser+"/"+osName]; ok {
+		return compiled, nil
+	}
+	return c.Browsers[browser][osName].AllVersions()
+}
+
+// allMetadata returns a copy of the metadata with that of flat builds folded in.
+func (p PlatformConfig) allMetadata() map[string]VersionMeta {
+	metadata := make(map[string]VersionMeta, len(p.Metadata)+len(p.Builds))
+	for key, meta := range p.Metadata {
+		metadata[key] = meta
+	}
+	for _, b := range p.Builds {
+		if b.VersionMeta == (VersionMeta{}) {
+			continue
+		}
+		if v, err := ParseVersion(b.Version); err == nil {
+			metadata[v.String()] = b.VersionMeta
+		}
+	}
+	return metadata
+}
+
+// setVersions replaces the versions of p with a version tree, or a flat
+// build list when the versions differ in depth, and sets its metadata.
+func (p *PlatformConfig) setVersions(versions []Version, metadata map[string]VersionMeta) {
+	p.Versions, p.Builds = versionTree(versions), nil
+	if p.Versions == nil && len(versions) > 0 {
+		for _, v := range versions {
+			p.Builds = append(p.Builds, Build{Version: v.String()})
+		}
+	}
+	p.Metadata = metadata
+	if len(metadata) == 0 {
+		p.Metadata = nil
+	}
+}
+
+// versionTree builds the nested version map of browsers.yaml, with the last
+// component of each version in a leaf list. It returns nil if the versions
+// differ in depth, which the tree cannot represent unambiguously.
+func versionTree(versions []Version) map[int]interface{} {
+	if len(versions) == 0 {
+		return nil
+	}
+	depth := len(versions[0].Components)
+	for _, v := range versions {
+		if len(v.Components) != depth {
+			return nil
+		}
+	}
+
+	tree := make(map[int]interface{})
+	for _, v := range sortVersions(append([]Version(nil), versions...)) {
+		c := v.Components
+		if depth == 1 {
+			tree[c[0]] = nil
+			continue
+		}
+		node := tree
+		for _, key := range c[:depth-2] {
+			next, ok := node[key].(map[int]interface{})
+			if !ok {
+				next = make(map[int]interface{})
+				node[key] = next
+			}
+			node = next
+		}
+		leaf, _ := node[c[depth-2]].([]int)
+		node[c[depth-2]] = append(leaf, c[depth-1])
+	}
+	return tree
+}
+
+// sortVersions sorts versions in place, oldest first, and returns them.
+func sortVersions(versions []Version) []Version {
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) < 0
+	})
+	return versions
+}
+
+// Export returns a copy of the dataset the Generator currently uses, with
+// overlays applied, e.g. to inspect the merged data or save it as YAML.
+// Versions are written as version trees even where the data used flat
+// builds or compiled tables.
+func (g *Generator) Export() *Config {
+	c := copyConfig(g.data().config)
+	c.MarketShare = append([]MarketShare(nil), c.MarketShare...)
+	for browser, entries := range c.Denylist {
+		c.Denylist[browser] = append([]DeniedBuild(nil), entries...)
+	}
+	for browser, platforms := range c.Browsers {
+		for osName, p := range platforms {
+			versions, _ := c.versionsOf(browser, osName) // Validated when loaded
+			p.Variants = append([]PlatformVariant(nil), p.Variants...)
+			p.Overrides = append([]Override(nil), p.Overrides...)
+			p.setVersions(versions, p.allMetadata())
+			platforms[osName] = p
+		}
+	}
+	c.compiled = nil
+	return c
+}
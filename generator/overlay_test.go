@@ -0,0 +1,157 @@
+package useragent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testOverlay = `
+delete:
+    - browser: chrome
+      versions: "133"
+replace:
+    chrome:
+        linux:
+            ua_template: "Linux Chrome/{{version}}"
+            versions:
+                140:
+                    0:
+                        7339: [80]
+browsers:
+    chrome:
+        windows:
+            versions:
+                150:
+                    0:
+                        7500: [12]
+            metadata:
+                150.0.7500.12:
+                    channel: beta
+    firefox:
+        linux:
+            ua_template: "Firefox/{{version}}"
+            builds:
+                - version: "140.0"
+`
+
+func TestOverlay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overlay.yaml")
+	if err := os.WriteFile(path, []byte(testOverlay), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewWithSource(Layered(Embedded(), path))
+	if err != nil {
+		t.Fatalf("NewWithSource failed: %v", err)
+	}
+
+	t.Run("Merge", func(t *testing.T) {
+		windows, err := g.Candidates(WithVersionConstraint("150.0.7500.12"))
+		if err != nil {
+			t.Fatalf("Candidates failed: %v", err)
+		}
+		if len(windows) == 0 || windows[0].Channel != "beta" {
+			t.Errorf("Expected merged version with its metadata, got %v", windows)
+		}
+		if _, err := g.Candidates(WithVersionConstraint("<150")); err != nil {
+			t.Errorf("Expected the embedded versions to be kept: %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, os := range []OSName{Windows, Android} {
+			if _, err := g.Generate(WithOS(os), WithVersionConstraint("133")); err == nil {
+				t.Errorf("Expected 133 to be deleted on %s", os)
+			}
+		}
+	})
+
+	t.Run("DeleteInherited", func(t *testing.T) {
+		overlay := &Overlay{Delete: []Deletion{{Browser: "chrome", OS: "android", Versions: "135"}}}
+		config, err := Embedded().Load()
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		merged, err := Merge(config, overlay)
+		if err != nil {
+			t.Fatalf("Merge failed: %v", err)
+		}
+		scoped, err := NewWithSource(FromConfig(merged))
+		if err != nil {
+			t.Fatalf("NewWithSource failed: %v", err)
+		}
+		if _, err := scoped.Generate(WithOS(Android), WithVersionConstraint("135")); err == nil {
+			t.Errorf("Expected 135 to be deleted on android")
+		}
+		for _, os := range []OSName{Windows, Linux} {
+			if _, err := scoped.Generate(WithOS(os), WithVersionConstraint("135")); err != nil {
+				t.Errorf("Expected 135 to be kept on %s: %v", os, err)
+			}
+		}
+		if _, err := scoped.Generate(WithOS(Android), WithVersionConstraint("136")); err != nil {
+			t.Errorf("Expected other android versions to be kept: %v", err)
+		}
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		res, err := g.Generate(WithOS(Linux))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.UserAgent != "Linux Chrome/140.0.7339.80" {
+			t.Errorf("Expected the replaced platform, got %q", res.UserAgent)
+		}
+		res, err = g.Generate(WithBrowser(Firefox), WithOS(Linux))
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.UserAgent != "Firefox/140.0" {
+			t.Errorf("Expected the added platform, got %q", res.UserAgent)
+		}
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		exported := g.Export()
+		if _, ok := exported.Browsers["firefox"]["linux"]; !ok {
+			t.Errorf("Expected the added platform in the export")
+		}
+		e, err := NewWithSource(FromConfig(exported))
+		if err != nil {
+			t.Fatalf("NewWithSource failed on the export: %v", err)
+		}
+		if added, removed := diffStores(g.data(), e.data()); added != 0 || removed != 0 {
+			t.Errorf("Export changed the data: %d added, %d removed", added, removed)
+		}
+	})
+
+	t.Run("BaseUnchanged", func(t *testing.T) {
+		base := mustDecode(t, testData)
+		overlay, err := decodeOverlay([]byte(testOverlay))
+		if err != nil {
+			t.Fatalf("decodeOverlay failed: %v", err)
+		}
+		merged, err := Merge(base, overlay)
+		if err != nil {
+			t.Fatalf("Merge failed: %v", err)
+		}
+		if len(merged.Browsers) != 2 || len(merged.Browsers["chrome"]) != 2 {
+			t.Errorf("Unexpected merged browsers: %v", merged.Browsers)
+		}
+		if len(base.Browsers) != 1 || len(base.Browsers["chrome"]) != 1 || base.Browsers["chrome"]["windows"].Metadata != nil {
+			t.Errorf("Merge modified the base config: %v", base.Browsers)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		overlay := &Overlay{Delete: []Deletion{{Browser: "opera"}}}
+		if _, err := Merge(mustDecode(t, testData), overlay); err == nil || !strings.Contains(err.Error(), "unknown browser") {
+			t.Errorf("Expected unknown browser error, got %v", err)
+		}
+
+		overlay = &Overlay{Delete: []Deletion{{Browser: "chrome", OS: "beos"}}}
+		if _, err := Merge(mustDecode(t, testData), overlay); err == nil || !strings.Contains(err.Error(), `unknown os "beos"`) {
+			t.Errorf("Expected unknown os error, got %v", err)
+		}
+	})
+}
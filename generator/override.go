@@ -0,0 +1,73 @@
+package useragent
+
+import "fmt"
+
+// versionOverride is an Override with its constraint parsed.
+type versionOverride struct {
+	versions    Constraint
+	uaTemplate  *uaTemplate // nil keeps the platform's
+	clientHints *bool
+	headers     map[string]string
+}
+
+// versionRules is what applies to one version once overrides are applied.
+type versionRules struct {
+	uaTemplate  *uaTemplate
+	clientHints bool
+	headers     map[string]string // Set or, when empty, removed; nil without overrides
+}
+
+// parseOverrides parses the constraints of a platform's overrides.
+func parseOverrides(overrides []Override) ([]versionOverride, error) {
+	parsed := make([]versionOverride, 0, len(overrides))
+	for i, o := range overrides {
+		if o.Versions == "" {
+			return nil, fmt.Errorf("override %d: missing versions", i)
+		}
+		c, err := ParseConstraint(o.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("override %d: %w", i, err)
+		}
+		if c.relative() {
+			return nil, fmt.Errorf("override %d: relative selectors are not allowed in %q", i, o.Versions)
+		}
+		var tmpl *uaTemplate
+		if o.UATemplate != "" {
+			if tmpl, err = parseTemplate(o.UATemplate); err != nil {
+				return nil, fmt.Errorf("override %d: %w", i, err)
+			}
+		}
+		parsed = append(parsed, versionOverride{
+			versions:    c,
+			uaTemplate:  tmpl,
+			clientHints: o.ClientHints,
+			headers:     o.Headers,
+		})
+	}
+	return parsed, nil
+}
+
+// rules returns the template and header rules of v.
+func (bd *browserData) rules(v Version) versionRules {
+	r := versionRules{uaTemplate: bd.uaTemplate, clientHints: true}
+	for _, o := range bd.overrides {
+		if !o.versions.Check(v) {
+			continue
+		}
+		if o.uaTemplate != nil {
+			r.uaTemplate = o.uaTemplate
+		}
+		if o.clientHints != nil {
+			r.clientHints = *o.clientHints
+		}
+		if len(o.headers) > 0 {
+			if r.headers == nil {
+				r.headers = make(map[string]string)
+			}
+			for name, value := range o.headers {
+				r.headers[name] = value
+			}
+		}
+	}
+	return r
+}
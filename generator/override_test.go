@@ -0,0 +1,81 @@
+package useragent
+
+import (
+	"strings"
+	"testing"
+)
+
+const overrideData = `
+browsers:
+    chrome:
+        windows:
+            ua_template: "Chrome/{{version}}"
+            versions:
+                100:
+                    0:
+                        4896: [60]
+                120:
+                    0:
+                        6099: [71]
+            metadata:
+                100.0.4896.60:
+                    engine: "100.0.4896.60"
+            overrides:
+                - versions: "<101"
+                  ua_template: "Old Chrome/{{version}}"
+                  client_hints: false
+                  headers:
+                      X-Legacy: "1"
+                - versions: "100"
+                  headers:
+                      X-Legacy: ""
+`
+
+func TestOverrides(t *testing.T) {
+	g, err := NewWithSource(Reader(strings.NewReader(overrideData)), WithStrictData())
+	if err != nil {
+		t.Fatalf("NewWithSource failed: %v", err)
+	}
+
+	t.Run("Template", func(t *testing.T) {
+		res, err := g.Generate(WithVersionConstraint("<101"), WithClientHints())
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.UserAgent != "Old Chrome/100.0.4896.60" {
+			t.Errorf("Expected the overridden template, got %q", res.UserAgent)
+		}
+		if _, ok := res.Headers["Sec-CH-UA"]; ok || len(res.Hints.Brands) > 0 {
+			t.Errorf("Expected no Client Hints for an override disabling them, got %v", res.Headers)
+		}
+		if _, ok := res.Headers["X-Legacy"]; ok {
+			t.Errorf("Expected the later override to remove X-Legacy, got %v", res.Headers)
+		}
+		if res.Candidate.Engine != "100.0.4896.60" {
+			t.Errorf("Expected engine version from metadata, got %q", res.Candidate.Engine)
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		res, err := g.Generate(WithVersionConstraint(">=120"), WithClientHints())
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.UserAgent != "Chrome/120.0.6099.71" {
+			t.Errorf("Expected the platform template, got %q", res.UserAgent)
+		}
+		if _, ok := res.Headers["Sec-CH-UA"]; !ok {
+			t.Errorf("Expected Client Hints, got %v", res.Headers)
+		}
+		if res.Candidate.Engine != "" {
+			t.Errorf("Expected unknown engine version, got %q", res.Candidate.Engine)
+		}
+	})
+
+	t.Run("RelativeSelector", func(t *testing.T) {
+		bad := strings.Replace(overrideData, `"<101"`, `"<latest"`, 1)
+		if _, err := NewWithSource(Reader(strings.NewReader(bad))); err == nil || !strings.Contains(err.Error(), "relative selectors") {
+			t.Errorf("Expected relative selector error, got %v", err)
+		}
+	})
+}
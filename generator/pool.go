@@ -0,0 +1,300 @@
+package useragent
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RotationPolicy decides which identity a key receives when it rotates.
+type RotationPolicy int
+
+const (
+	// RotateRandom picks a random identity on every rotation.
+	RotateRandom RotationPolicy = iota
+	// RotateRoundRobin cycles through the pool's identities in order.
+	// It requires a fixed pool size (see WithPoolSize).
+	RotateRoundRobin
+)
+
+// PoolOption configures a Pool.
+type PoolOption func(*poolOptions)
+
+type poolOptions struct {
+	generate       []Option
+	policy         RotationPolicy
+	size           int           // 0 means a fresh identity is generated on every rotation
+	ttl            time.Duration // 0 means identities never expire by age
+	maxUses        int           // 0 means identities never expire by use count
+	rotateOnError  bool
+	rotateOnStatus map[int]bool
+}
+
+// WithGenerateOptions sets the options passed to Generate when the pool creates identities.
+func WithGenerateOptions(opts ...Option) PoolOption {
+	return func(o *poolOptions) {
+		o.generate = opts
+	}
+}
+
+// WithRotationPolicy sets how the next identity is chosen on rotation.
+func WithRotationPolicy(p RotationPolicy) PoolOption {
+	return func(o *poolOptions) {
+		o.policy = p
+	}
+}
+
+// WithPoolSize pre-generates n identities that keys rotate through.
+// With the default of 0 every rotation generates a fresh identity.
+func WithPoolSize(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.size = n
+	}
+}
+
+// WithTTL keeps an identity sticky to its key for at most d.
+func WithTTL(d time.Duration) PoolOption {
+	return func(o *poolOptions) {
+		o.ttl = d
+	}
+}
+
+// WithMaxUses keeps an identity sticky to its key for at most n calls to Get.
+func WithMaxUses(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.maxUses = n
+	}
+}
+
+// WithRotateOnError enables or disables rotation when ReportError is called (default: enabled).
+func WithRotateOnError(enable bool) PoolOption {
+	return func(o *poolOptions) {
+		o.rotateOnError = enable
+	}
+}
+
+// WithRotateOnStatus sets the HTTP status codes that trigger rotation when
+// passed to ReportStatus (default: 403 and 429).
+func WithRotateOnStatus(codes ...int) PoolOption {
+	return func(o *poolOptions) {
+		o.rotateOnStatus = make(map[int]bool, len(codes))
+		for _, c := range codes {
+			o.rotateOnStatus[c] = true
+		}
+	}
+}
+
+// Pool hands out identities keyed by an arbitrary string (target host, proxy,
+// account) and keeps them sticky until a TTL, use count or feedback rotates them.
+// It is safe for concurrent use.
+type Pool struct {
+	gen  *Generator
+	opts poolOptions
+	now  func() time.Time
+
+	mu      sync.Mutex
+	leases  map[string]*lease
+	sweepAt int       // lease count that triggers the next sweep
+	members []*Result // fixed identity set when size > 0
+	next    int       // round-robin cursor into members
+}
+
+// minSweep is the lease count below which expired leases are left to be
+// rotated on access.
+const minSweep = 64
+
+// lease tracks the identity currently assigned to a key.
+type lease struct {
+	result *Result
+	member int // index into Pool.members, -1 for fresh identities
+	issued time.Time
+	uses   int
+}
+
+// NewPool creates a Pool that draws identities from g.
+func NewPool(g *Generator, opts ...PoolOption) (*Pool, error) {
+	if g == nil {
+		return nil, errors.New("generator is nil")
+	}
+	options := poolOptions{
+		rotateOnError:  true,
+		rotateOnStatus: map[int]bool{403: true, 429: true},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.size < 0 {
+		return nil, fmt.Errorf("invalid pool size %d", options.size)
+	}
+	if options.policy == RotateRoundRobin && options.size == 0 {
+		return nil, errors.New("round-robin rotation requires a fixed pool size")
+	}
+
+	p := &Pool{
+		gen:     g,
+		opts:    options,
+		now:     time.Now,
+		leases:  make(map[string]*lease),
+		sweepAt: minSweep,
+	}
+	for i := 0; i < options.size; i++ {
+		res, err := g.Generate(options.generate...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fill pool: %w", err)
+		}
+		p.members = append(p.members, res)
+	}
+	return p, nil
+}
+
+// Get returns the identity assigned to key, assigning or rotating it as needed.
+// The returned Result is a copy and may be modified by the caller.
+func (p *Pool) Get(key string) (*Result, error) {
+	p.mu.Lock()
+	if l := p.lease(key); l != nil {
+		l.uses++
+		res := l.result.clone()
+		p.mu.Unlock()
+		return res, nil
+	}
+	p.mu.Unlock()
+
+	// Fresh identities are generated without the lock so other keys are
+	// not held up.
+	res, err := p.gen.Generate(p.opts.generate...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// A concurrent Get may have assigned key an identity in the meantime.
+	l := p.lease(key)
+	if l == nil {
+		l = &lease{result: res, member: -1, issued: p.now()}
+		p.leases[key] = l
+	}
+	l.uses++
+	return l.result.clone(), nil
+}
+
+// lease returns the live lease of key, rotating an expired one, or nil if
+// key needs a freshly generated identity. The caller holds p.mu.
+func (p *Pool) lease(key string) *lease {
+	p.sweep()
+	l := p.leases[key]
+	if l != nil && p.expired(l) {
+		l = p.rotate(key, l)
+	}
+	if l == nil && len(p.members) > 0 {
+		l = p.assign(key, -1)
+	}
+	return l
+}
+
+// sweep drops expired leases once their number has doubled since the last
+// sweep, so keys that never come back do not accumulate. The caller holds p.mu.
+func (p *Pool) sweep() {
+	if len(p.leases) < p.sweepAt || (p.opts.ttl == 0 && p.opts.maxUses == 0) {
+		return
+	}
+	for key, l := range p.leases {
+		if p.expired(l) {
+			delete(p.leases, key)
+		}
+	}
+	p.sweepAt = max(2*len(p.leases), minSweep)
+}
+
+// Rotate forces key to receive a new identity on its next Get.
+func (p *Pool) Rotate(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if l, ok := p.leases[key]; ok {
+		p.rotate(key, l)
+	}
+}
+
+// ReportError records a failed request made with key's identity.
+func (p *Pool) ReportError(key string, err error) {
+	if err == nil || !p.opts.rotateOnError {
+		return
+	}
+	p.Rotate(key)
+}
+
+// ReportStatus records the HTTP status of a request made with key's identity.
+func (p *Pool) ReportStatus(key string, status int) {
+	if !p.opts.rotateOnStatus[status] {
+		return
+	}
+	p.Rotate(key)
+}
+
+// Forget drops the identity assigned to key.
+func (p *Pool) Forget(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.leases, key)
+}
+
+// Len returns the number of keys that currently hold an identity.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.leases)
+}
+
+func (p *Pool) expired(l *lease) bool {
+	if p.opts.ttl > 0 && p.now().Sub(l.issued) >= p.opts.ttl {
+		return true
+	}
+	if p.opts.maxUses > 0 && l.uses >= p.opts.maxUses {
+		return true
+	}
+	return false
+}
+
+// rotate replaces the lease for key. Fresh identities are generated lazily by Get,
+// so without pool members the key is simply left without a lease.
+func (p *Pool) rotate(key string, old *lease) *lease {
+	delete(p.leases, key)
+	if len(p.members) == 0 {
+		return nil
+	}
+	return p.assign(key, old.member)
+}
+
+// assign gives key a pool member, avoiding the one it held before when possible.
+func (p *Pool) assign(key string, previous int) *lease {
+	l := &lease{issued: p.now()}
+	l.member = p.pick(previous)
+	l.result = p.members[l.member]
+	p.leases[key] = l
+	return l
+}
+
+func (p *Pool) pick(previous int) int {
+	n := len(p.members)
+	switch p.opts.policy {
+	case RotateRoundRobin:
+		i := p.next % n
+		if i == previous && n > 1 {
+			i = (i + 1) % n
+		}
+		p.next = i + 1
+		return i
+	default:
+		if previous < 0 || n == 1 {
+			return p.gen.intn(n)
+		}
+		// Draw from the other n-1 members so rotation always changes identity.
+		i := p.gen.intn(n - 1)
+		if i >= previous {
+			i++
+		}
+		return i
+	}
+}
@@ -0,0 +1,147 @@
+package useragent
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	t.Run("Sticky", func(t *testing.T) {
+		p, err := NewPool(g)
+		if err != nil {
+			t.Fatalf("NewPool failed: %v", err)
+		}
+		first, err := p.Get("example.com")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		for i := 0; i < 5; i++ {
+			res, _ := p.Get("example.com")
+			if res.UserAgent != first.UserAgent {
+				t.Fatalf("Identity changed without rotation: %s != %s", res.UserAgent, first.UserAgent)
+			}
+		}
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		p, err := NewPool(g, WithPoolSize(3), WithTTL(time.Minute))
+		if err != nil {
+			t.Fatalf("NewPool failed: %v", err)
+		}
+		now := time.Now()
+		p.now = func() time.Time { return now }
+
+		p.Get("k")
+		before := p.leases["k"].member
+		now = now.Add(2 * time.Minute)
+		p.Get("k")
+		if after := p.leases["k"].member; after == before {
+			t.Errorf("Expected rotation after TTL, still on member %d", after)
+		}
+	})
+
+	t.Run("Sweep", func(t *testing.T) {
+		p, err := NewPool(g, WithTTL(time.Minute))
+		if err != nil {
+			t.Fatalf("NewPool failed: %v", err)
+		}
+		now := time.Now()
+		p.now = func() time.Time { return now }
+
+		for i := 0; i < 100; i++ {
+			p.Get(fmt.Sprintf("old-%d", i))
+		}
+		now = now.Add(2 * time.Minute)
+		for i := 0; i < 100; i++ {
+			p.Get(fmt.Sprintf("new-%d", i))
+		}
+		if n := p.Len(); n != 100 {
+			t.Errorf("Expected expired leases to be swept, %d keys left", n)
+		}
+	})
+
+	t.Run("MaxUsesRoundRobin", func(t *testing.T) {
+		p, err := NewPool(g, WithPoolSize(3), WithRotationPolicy(RotateRoundRobin), WithMaxUses(2))
+		if err != nil {
+			t.Fatalf("NewPool failed: %v", err)
+		}
+		var members []int
+		for i := 0; i < 6; i++ {
+			p.Get("k")
+			members = append(members, p.leases["k"].member)
+		}
+		want := []int{0, 0, 1, 1, 2, 2}
+		for i := range want {
+			if members[i] != want[i] {
+				t.Fatalf("Expected members %v, got %v", want, members)
+			}
+		}
+	})
+
+	t.Run("Feedback", func(t *testing.T) {
+		p, err := NewPool(g, WithPoolSize(2))
+		if err != nil {
+			t.Fatalf("NewPool failed: %v", err)
+		}
+		p.Get("k")
+		before := p.leases["k"].member
+
+		p.ReportStatus("k", 200)
+		if p.leases["k"].member != before {
+			t.Error("Rotated on status 200")
+		}
+		p.ReportStatus("k", 429)
+		if p.leases["k"].member == before {
+			t.Error("Expected rotation on status 429")
+		}
+		before = p.leases["k"].member
+		p.ReportError("k", errors.New("connection reset"))
+		if p.leases["k"].member == before {
+			t.Error("Expected rotation on error")
+		}
+	})
+
+	t.Run("RoundRobinRequiresSize", func(t *testing.T) {
+		if _, err := NewPool(g, WithRotationPolicy(RotateRoundRobin)); err == nil {
+			t.Error("Expected error for round-robin without pool size")
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		p, err := NewPool(g, WithMaxUses(3))
+		if err != nil {
+			t.Fatalf("NewPool failed: %v", err)
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				key := string(rune('a' + i%3))
+				for j := 0; j < 50; j++ {
+					res, err := p.Get(key)
+					if err != nil {
+						t.Errorf("Get failed: %v", err)
+						return
+					}
+					res.Headers["X-Test"] = "mutated"
+					if j%10 == 0 {
+						p.ReportStatus(key, 403)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		if p.Len() != 3 {
+			t.Errorf("Expected 3 keys, got %d", p.Len())
+		}
+	})
+}
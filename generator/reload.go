@@ -0,0 +1,135 @@
+package useragent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReloadEvent reports the outcome of a reload.
+type ReloadEvent struct {
+	Time time.Time
+	// Err is the reason the reload failed; the previous data stays in use.
+	Err error
+	// Added and Removed count the versions, over all browser/OS pairs, that
+	// the new data added and removed.
+	Added   int
+	Removed int
+}
+
+// Changed reports whether the reload succeeded and changed the versions.
+func (e ReloadEvent) Changed() bool {
+	return e.Err == nil && (e.Added > 0 || e.Removed > 0)
+}
+
+// Reload loads the Generator's data source again, validates the result and
+// swaps it in atomically. Concurrent Generate calls are never blocked: they
+// finish with the data they started with. On failure the current data is
+// kept and the event's Err is returned.
+func (g *Generator) Reload() (ReloadEvent, error) {
+	g.reloadMu.Lock()
+	defer g.reloadMu.Unlock()
+
+	ev := ReloadEvent{Time: time.Now()}
+	store, err := loadStore(g.source, g.strict)
+	if err == nil && store.empty() {
+		err = errors.New("dataset has no versions")
+	}
+	if err != nil {
+		ev.Err = err
+		return ev, err
+	}
+
+	ev.Added, ev.Removed = diffStores(g.data(), store)
+	g.store.Store(store)
+	return ev, nil
+}
+
+// Watch polls the data source every interval and reloads it until ctx is
+// done, calling onReload (if not nil) with the outcome of every reload. A
+// File source is only reloaded after its size or modification time changed;
+// other sources are reloaded on every tick. Watch blocks, so run it in its
+// own goroutine; it returns ctx.Err(), or an error right away if interval
+// is not positive.
+//
+// Replace the file atomically (write elsewhere, then rename) so a reload
+// never sees it half-written.
+func (g *Generator) Watch(ctx context.Context, interval time.Duration, onReload func(ReloadEvent)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid watch interval %v", interval)
+	}
+	file, isFile := g.source.(fileSource)
+	stamp := func() string {
+		s, err := file.stamp()
+		if err != nil {
+			return err.Error() // A missing file is reported once, by the reload
+		}
+		return s
+	}
+	last := ""
+	if isFile {
+		last = stamp()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if isFile {
+			current := stamp()
+			if current == last {
+				continue
+			}
+			last = current
+		}
+		ev, _ := g.Reload()
+		if onReload != nil {
+			onReload(ev)
+		}
+	}
+}
+
+// empty reports whether the store holds no versions at all.
+func (s *dataStore) empty() bool {
+	for _, platforms := range s.data {
+		for _, bd := range platforms {
+			if len(bd.versions) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// diffStores counts the versions in next that are not in prev and vice versa.
+func diffStores(prev, next *dataStore) (added, removed int) {
+	keys := func(s *dataStore) map[string]bool {
+		set := make(map[string]bool)
+		for browser, platforms := range s.data {
+			for os, bd := range platforms {
+				for _, v := range bd.versions {
+					set[string(browser)+"/"+string(os)+"/"+v.String()] = true
+				}
+			}
+		}
+		return set
+	}
+	before, after := keys(prev), keys(next)
+	for k := range after {
+		if !before[k] {
+			added++
+		}
+	}
+	for k := range before {
+		if !after[k] {
+			removed++
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,113 @@
+package useragent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "browsers.yaml")
+	write := func(data string) {
+		t.Helper()
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(testData)
+
+	g, err := NewWithSource(File(path))
+	if err != nil {
+		t.Fatalf("NewWithSource failed: %v", err)
+	}
+
+	t.Run("Reload", func(t *testing.T) {
+		write(strings.Replace(testData, "[12]", "[12, 20, 31]", 1))
+		ev, err := g.Reload()
+		if err != nil {
+			t.Fatalf("Reload failed: %v", err)
+		}
+		if ev.Added != 2 || ev.Removed != 0 || !ev.Changed() {
+			t.Errorf("Unexpected diff %+v", ev)
+		}
+
+		write("browsers: [broken")
+		if _, err := g.Reload(); err == nil {
+			t.Error("Expected error for malformed data")
+		}
+		write("browsers: {}")
+		if _, err := g.Reload(); err == nil {
+			t.Error("Expected error for data without versions")
+		}
+		// Failed reloads keep the previous data
+		if _, err := g.Generate(WithMinVersion("150.0.7500.31")); err != nil {
+			t.Errorf("Previous data lost: %v", err)
+		}
+	})
+
+	t.Run("ConcurrentGenerate", func(t *testing.T) {
+		write(testData)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 200; j++ {
+					if _, err := g.Generate(); err != nil {
+						t.Errorf("Generate failed: %v", err)
+						return
+					}
+				}
+			}()
+		}
+		for i := 0; i < 20; i++ {
+			if _, err := g.Reload(); err != nil {
+				t.Errorf("Reload failed: %v", err)
+			}
+		}
+		wg.Wait()
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		events := make(chan ReloadEvent, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- g.Watch(ctx, 10*time.Millisecond, func(ev ReloadEvent) { events <- ev }) }()
+
+		// Give the watcher time to record the initial file state
+		time.Sleep(30 * time.Millisecond)
+		write(strings.Replace(testData, "7500: [12]", "7500: [12]\n                        7501: [1]", 1))
+
+		select {
+		case ev := <-events:
+			if ev.Err != nil || ev.Added != 1 {
+				t.Errorf("Unexpected event %+v", ev)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Watch did not reload the changed file")
+		}
+		if _, err := g.Generate(WithMinVersion("150.0.7501")); err != nil {
+			t.Errorf("New data not in use: %v", err)
+		}
+
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+
+		// A non-positive interval is refused instead of panicking
+		for _, interval := range []time.Duration{0, -time.Second} {
+			if err := g.Watch(context.Background(), interval, nil); err == nil {
+				t.Errorf("Expected error for interval %v", interval)
+			}
+		}
+	})
+}
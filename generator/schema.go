@@ -0,0 +1,395 @@
+package useragent
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DataError is a problem found in browser data by ValidateData.
+type DataError struct {
+	Path   []string // Keys leading to the problem, e.g. browsers, chrome, windows, ua_template
+	Line   int      // 1-based line in the data file, 0 when unknown
+	Column int
+	Msg    string
+}
+
+func (e DataError) Error() string {
+	path := strings.Join(e.Path, ".")
+	switch {
+	case e.Line > 0 && path == "":
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+	case e.Line > 0:
+		return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, path, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", path, e.Msg)
+}
+
+// DataErrors lists every problem found in browser data.
+type DataErrors []DataError
+
+func (e DataErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	if len(e) == 1 {
+		return lines[0]
+	}
+	return fmt.Sprintf("%d problems:\n%s", len(e), strings.Join(lines, "\n"))
+}
+
+// WithStrictData makes the Generator validate its data with ValidateData
+// when it is created and on every reload, so mistakes that loading would
+// tolerate or silently drop are reported instead.
+func WithStrictData() GeneratorOption {
+	return func(c *generatorConfig) {
+		c.strict = true
+	}
+}
+
+var (
+	knownBrowsers = []BrowserName{Chrome, Firefox, Safari, Edge}
+	knownOSes     = []OSName{Windows, Linux, MacOS, Android, IOS, ChromeOS}
+)
+
+// ValidateData strictly checks data in the format of browsers.yaml and
+// returns every problem found as DataErrors, or nil. Besides what loading
+// rejects, including values the decoder had to skip, it reports unknown
+// browser and OS names, version keys that are not integers, duplicate
+// versions, version trees of mixed depth, platforms without versions and
+// templates that are empty, malformed or have no version placeholder.
+// Errors carry the line and column when the data was decoded from YAML or
+// JSON with the yamldata package imported.
+func ValidateData(config *Config) error {
+	c := &dataChecker{config: config}
+	c.errs = append(c.errs, config.decodeErrs...)
+	c.check()
+	if len(c.errs) == 0 {
+		return nil
+	}
+	if config.locate != nil {
+		for i := range c.errs {
+			if c.errs[i].Line == 0 {
+				c.errs[i].Line, c.errs[i].Column = config.locate(c.errs[i].Path)
+			}
+		}
+	}
+	return c.errs
+}
+
+type dataChecker struct {
+	config *Config
+	errs   DataErrors
+}
+
+func (c *dataChecker) add(path []string, format string, args ...interface{}) {
+	c.errs = append(c.errs, DataError{
+		Path: append([]string(nil), path...),
+		Msg:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *dataChecker) check() {
+	if c.config.SchemaVersion > CurrentSchemaVersion {
+		c.add([]string{"schema_version"}, "version %d is newer than the supported version %d", c.config.SchemaVersion, CurrentSchemaVersion)
+	} else if c.config.SchemaVersion < 0 {
+		c.add([]string{"schema_version"}, "invalid version %d", c.config.SchemaVersion)
+	}
+	if len(c.config.Browsers) == 0 {
+		c.add([]string{"browsers"}, "no browsers defined")
+	}
+
+	for _, browser := range sortedKeys(c.config.Browsers) {
+		path := []string{"browsers", browser}
+		if !isKnownBrowser(BrowserName(browser)) {
+			c.add(path, "unknown browser %q", browser)
+		}
+		platforms := c.config.Browsers[browser]
+		for _, osName := range sortedKeys(platforms) {
+			c.checkPlatform(append(path, osName), browser, osName)
+		}
+	}
+
+	for _, browser := range sortedKeys(c.config.Denylist) {
+		path := []string{"denylist", browser}
+		platforms, ok := c.config.Browsers[browser]
+		if !ok {
+			c.add(path, "unknown browser %q", browser)
+		}
+		for i, entry := range c.config.Denylist[browser] {
+			entryPath := append(path, strconv.Itoa(i))
+			if _, err := ParseVersion(entry.Version); err != nil {
+				c.add(append(entryPath, "version"), "%v", err)
+			}
+			if _, ok := platforms[string(entry.OS)]; entry.OS != "" && !ok {
+				c.add(append(entryPath, "os"), "unknown os %q for browser %s", entry.OS, browser)
+			}
+		}
+	}
+
+	// Generation skips entries without data, which silently shifts their
+	// share to the others.
+	for i, ms := range c.config.MarketShare {
+		path := []string{"market_share", strconv.Itoa(i)}
+		p, ok := c.config.Browsers[string(ms.Browser)][string(ms.OS)]
+		device := p.Device
+		if device == "" {
+			device = Desktop
+		}
+		switch {
+		case ms.Device != "" && !isKnownDevice(ms.Device):
+			c.add(append(path, "device"), "unknown device class %q", ms.Device)
+		case !ok:
+			c.add(path, "no data for %s/%s", ms.Browser, ms.OS)
+		case ms.Device != "" && ms.Device != device:
+			c.add(append(path, "device"), "%s/%s is %s, not %s", ms.Browser, ms.OS, device, ms.Device)
+		}
+		if ms.Share < 0 {
+			c.add(append(path, "share"), "share is negative")
+		}
+	}
+}
+
+func (c *dataChecker) checkPlatform(path []string, browser, osName string) {
+	platforms := c.config.Browsers[browser]
+	p := platforms[osName]
+	if !isKnownOS(OSName(osName)) {
+		c.add(path, "unknown os %q", osName)
+	}
+	c.checkTemplate(append(path, "ua_template"), p.UATemplate)
+	if p.Device != "" && !isKnownDevice(p.Device) {
+		c.add(append(path, "device"), "unknown device class %q", p.Device)
+	}
+	for i, v := range p.Variants {
+		if v.Weight < 0 {
+			c.add(append(path, "variants", strconv.Itoa(i), "weight"), "weight is negative")
+		}
+	}
+
+	if p.VersionsFrom != "" {
+		source, ok := platforms[p.VersionsFrom]
+		switch {
+		case !ok || p.VersionsFrom == osName:
+			c.add(append(path, "versions_from"), "unknown platform %q", p.VersionsFrom)
+		case len(source.Versions) == 0 && len(source.Builds) == 0 && c.config.compiled[browser+"/"+p.VersionsFrom] == nil:
+			c.add(append(path, "versions_from"), "platform %q has no versions of its own", p.VersionsFrom)
+		}
+	}
+
+	// Versions from the tree and the flat list, keyed by string to find duplicates
+	seen := make(map[string]bool)
+	depths := make(map[int][]string)
+	note := func(vpath []string, v Version) {
+		key := v.String()
+		if seen[key] {
+			c.add(vpath, "duplicate version %s", key)
+			return
+		}
+		seen[key] = true
+		depths[len(v.Components)] = append(depths[len(v.Components)], key)
+	}
+	if p.Versions != nil {
+		c.checkTree(append(path, "versions"), nil, p.Versions, note)
+	}
+	for _, v := range c.config.compiled[browser+"/"+osName] {
+		note(append(path, "versions"), v)
+	}
+	for i, b := range p.Builds {
+		bpath := append(path, "builds", strconv.Itoa(i))
+		v, err := ParseVersion(b.Version)
+		if err != nil {
+			c.add(append(bpath, "version"), "%v", err)
+			continue
+		}
+		note(append(bpath, "version"), v)
+		c.checkMeta(bpath, b.VersionMeta)
+	}
+	if len(seen) == 0 && p.VersionsFrom == "" {
+		c.add(path, "no versions")
+	}
+	if len(depths) > 1 {
+		var parts []string
+		for _, depth := range sortedIntKeys(depths) {
+			parts = append(parts, fmt.Sprintf("%d with %d components (e.g. %s)", len(depths[depth]), depth, depths[depth][0]))
+		}
+		c.add(append(path, "versions"), "mixed-depth version tree: %s", strings.Join(parts, ", "))
+	}
+
+	for i, o := range p.Overrides {
+		opath := append(path, "overrides", strconv.Itoa(i))
+		if versions, err := ParseConstraint(o.Versions); err != nil {
+			c.add(append(opath, "versions"), "%v", err)
+		} else if versions.relative() {
+			c.add(append(opath, "versions"), "relative selectors are not allowed")
+		}
+		if o.UATemplate != "" {
+			c.checkTemplate(append(opath, "ua_template"), o.UATemplate)
+		}
+	}
+
+	for _, key := range sortedKeys(p.Metadata) {
+		mpath := append(path, "metadata", key)
+		if _, err := ParseVersion(key); err != nil {
+			c.add(mpath, "%v", err)
+		}
+		c.checkMeta(mpath, p.Metadata[key])
+	}
+}
+
+func (c *dataChecker) checkTemplate(path []string, template string) {
+	if strings.TrimSpace(template) == "" {
+		c.add(path, "empty template")
+		return
+	}
+	tmpl, err := parseTemplate(template)
+	if err != nil {
+		c.add(path, "%v", err)
+		return
+	}
+	if !tmpl.uses("version") && !tmpl.uses("major") && !tmpl.uses("reduced_version") {
+		c.add(path, "template has no {{version}}, {{major}} or {{reduced_version}} placeholder")
+	}
+}
+
+func (c *dataChecker) checkMeta(path []string, meta VersionMeta) {
+	if meta.Released != "" {
+		if _, err := time.Parse(dateLayout, meta.Released); err != nil {
+			c.add(append(path, "released"), "invalid date %q, want YYYY-MM-DD", meta.Released)
+		}
+	}
+	if meta.EOL != "" {
+		if _, err := time.Parse(dateLayout, meta.EOL); err != nil {
+			c.add(append(path, "eol"), "invalid date %q, want YYYY-MM-DD", meta.EOL)
+		}
+	}
+}
+
+// checkTree walks a version subtree the way parseVersions does, reporting
+// everything parseVersions would drop or truncate.
+func (c *dataChecker) checkTree(path []string, prefix []int, node interface{}, note func([]string, Version)) {
+	child := func(key int) []int {
+		return append(prefix[:len(prefix):len(prefix)], key)
+	}
+
+	switch v := node.(type) {
+	case nil:
+		note(path, Version{Components: prefix})
+	case map[int]interface{}:
+		for _, key := range sortedVersionKeys(v) {
+			if key < 0 {
+				c.add(append(path, strconv.Itoa(key)), "version component %d is negative", key)
+				continue
+			}
+			c.checkTree(append(path, strconv.Itoa(key)), child(key), v[key], note)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			n, err := strconv.Atoi(key)
+			if err != nil || n < 0 {
+				c.add(append(path, key), "version key %q is not a non-negative integer", key)
+				continue
+			}
+			c.checkTree(append(path, key), child(n), v[key], note)
+		}
+	case map[interface{}]interface{}:
+		keys := make([]interface{}, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			if n, ok := c.component(append(path, fmt.Sprint(key)), key); ok {
+				c.checkTree(append(path, fmt.Sprint(key)), child(n), v[key], note)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if n, ok := c.component(append(path, strconv.Itoa(i)), item); ok {
+				note(append(path, strconv.Itoa(i)), Version{Components: child(n)})
+			}
+		}
+	case []int:
+		for i, n := range v {
+			note(append(path, strconv.Itoa(i)), Version{Components: child(n)})
+		}
+	default:
+		c.add(path, "unexpected value %v, want a map, a list of numbers or nothing", v)
+	}
+}
+
+// component converts a version component the way toInt does and reports
+// values it would drop or truncate.
+func (c *dataChecker) component(path []string, value interface{}) (int, bool) {
+	n, ok := toInt(value)
+	switch {
+	case !ok:
+		c.add(path, "version component %v is not an integer", value)
+		return 0, false
+	case n < 0:
+		c.add(path, "version component %d is negative", n)
+		return 0, false
+	}
+	if f, isFloat := value.(float64); isFloat && f != math.Trunc(f) {
+		c.add(path, "version component %v is not an integer and would be truncated", f)
+		return 0, false
+	}
+	return n, true
+}
+
+func isKnownBrowser(b BrowserName) bool {
+	for _, known := range knownBrowsers {
+		if b == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownOS(os OSName) bool {
+	for _, known := range knownOSes {
+		if os == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownDevice(d DeviceClass) bool {
+	return d == Desktop || d == Mobile || d == Tablet
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedVersionKeys(m map[int]interface{}) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func sortedIntKeys(m map[int][]string) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,123 @@
+package useragent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateData(t *testing.T) {
+	t.Run("Embedded", func(t *testing.T) {
+		if _, err := New(WithStrictData()); err != nil {
+			t.Fatalf("Embedded data failed strict validation: %v", err)
+		}
+		if err := ValidateData(mustDecode(t, testData)); err != nil {
+			t.Errorf("Expected valid test data, got %v", err)
+		}
+	})
+
+	t.Run("Problems", func(t *testing.T) {
+		config := mustDecode(t, `
+browsers:
+    chrome:
+        windows:
+            ua_template: "Chrome/{{version}} {{cpu}}"
+            versions:
+                150:
+                    0:
+                        7500: [12, 12, 1.5]
+                        x: [1]
+                    1: ~
+        beos:
+            ua_template: ""
+    netscape:
+        windows:
+            ua_template: "Netscape"
+            builds:
+                - version: "4.x"
+market_share:
+    - browser: chrome
+      os: windows
+      device: mobile
+      share: 0.5
+    - browser: safari
+      os: ios
+      share: 0.5
+`)
+		err := ValidateData(config)
+		var problems DataErrors
+		if !errors.As(err, &problems) {
+			t.Fatalf("Expected DataErrors, got %v", err)
+		}
+
+		want := map[string]string{
+			"browsers.chrome.beos":                          `unknown os "beos"`,
+			"browsers.chrome.beos.ua_template":              "empty template",
+			"browsers.chrome.windows.ua_template":           "unknown placeholder {{cpu}}",
+			"browsers.chrome.windows.versions.150.0.7500.1": "duplicate version 150.0.7500.12",
+			"browsers.chrome.windows.versions.150.0.7500.2": "would be truncated",
+			"browsers.chrome.windows.versions.150.0.x":      "not an integer",
+			"browsers.chrome.windows.versions":              "mixed-depth version tree",
+			"browsers.netscape":                             `unknown browser "netscape"`,
+			"browsers.netscape.windows.ua_template":         "has no {{version}}",
+			"browsers.netscape.windows.builds.0.version":    "invalid version",
+			"market_share.0.device":                         "chrome/windows is desktop, not mobile",
+			"market_share.1":                                "no data for safari/ios",
+		}
+		for path, msg := range want {
+			found := false
+			for _, p := range problems {
+				if strings.Join(p.Path, ".") == path && strings.Contains(p.Msg, msg) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Missing problem %q at %s in:\n%v", msg, path, err)
+			}
+		}
+	})
+
+	t.Run("DecodeErrors", func(t *testing.T) {
+		// The key is skipped, so the rest of the data is still checked
+		config := mustDecode(t, `{"browsers": {"chrome": {"windows": {
+			"ua_template": "Chrome",
+			"versions": {"abc": null, "150": {"0": {"7500": [12]}}}
+		}}}}`)
+		err := ValidateData(config)
+		var problems DataErrors
+		if !errors.As(err, &problems) || len(problems) != 2 {
+			t.Fatalf("Expected two problems, got %v", err)
+		}
+		if path := strings.Join(problems[0].Path, "."); path != "browsers.chrome.windows.versions.abc" {
+			t.Errorf("Expected the key to be reported, got %v", problems[0])
+		}
+		if !strings.Contains(problems[1].Msg, "has no {{version}}") {
+			t.Errorf("Expected the template to be checked, got %v", problems[1])
+		}
+
+		if _, err := buildStore(config); !errors.As(err, &problems) {
+			t.Errorf("Expected buildStore to refuse the data, got %v", err)
+		}
+	})
+
+	t.Run("StrictGenerator", func(t *testing.T) {
+		bad := strings.Replace(testData, "Chrome/{{version}}", "Chrome", 1)
+		if _, err := NewWithSource(Reader(strings.NewReader(bad))); err != nil {
+			t.Fatalf("NewWithSource failed without strict validation: %v", err)
+		}
+		_, err := NewWithSource(Reader(strings.NewReader(bad)), WithStrictData())
+		var problems DataErrors
+		if !errors.As(err, &problems) {
+			t.Fatalf("Expected DataErrors, got %v", err)
+		}
+	})
+}
+
+func mustDecode(t *testing.T, content string) *Config {
+	t.Helper()
+	config, err := decodeConfig([]byte(content))
+	if err != nil {
+		t.Fatalf("decodeConfig failed: %v", err)
+	}
+	return config
+}
@@ -0,0 +1,88 @@
+package useragent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Relative version selectors. They name a major version of the browser/OS
+// pair being generated and are resolved when generating, so configurations
+// do not go stale as new versions are released.
+const (
+	// SelectorLatest is the newest major version in the data.
+	SelectorLatest = "latest"
+	// SelectorPreviousMajor is the major version before the newest, same as "latest-1".
+	SelectorPreviousMajor = "previous-major"
+	// SelectorOldestSupported is the oldest major version whose end-of-life
+	// date is unknown or not yet reached at the reference time.
+	SelectorOldestSupported = "oldest-supported"
+)
+
+// isSelector reports whether s looks like a relative selector rather than a
+// version number.
+func isSelector(s string) bool {
+	return s != "" && (s[0] >= 'a' && s[0] <= 'z' || s[0] >= 'A' && s[0] <= 'Z')
+}
+
+// checkSelector validates a selector's syntax.
+func checkSelector(s string) error {
+	_, err := selectorOffset(s)
+	return err
+}
+
+// selectorOffset returns how many majors behind the newest s points, or -1
+// for SelectorOldestSupported.
+func selectorOffset(s string) (int, error) {
+	switch s {
+	case SelectorLatest:
+		return 0, nil
+	case SelectorPreviousMajor:
+		return 1, nil
+	case SelectorOldestSupported:
+		return -1, nil
+	}
+	if rest, ok := strings.CutPrefix(s, SelectorLatest+"-"); ok {
+		n, err := strconv.Atoi(rest)
+		if err == nil && n >= 0 && !strings.HasPrefix(rest, "+") {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown version selector %q (want latest, latest-N, previous-major or oldest-supported)", s)
+}
+
+// resolveSelector returns the major version a selector denotes in bd.
+// latest-N counts the majors present in the data, skipping gaps.
+func resolveSelector(s string, bd *browserData, now time.Time) (int, error) {
+	offset, err := selectorOffset(s)
+	if err != nil {
+		return 0, err
+	}
+
+	// Versions are sorted newest first
+	var majors []int
+	for _, v := range bd.versions {
+		m := majorComponent(v)
+		if len(majors) == 0 || majors[len(majors)-1] != m {
+			majors = append(majors, m)
+		}
+	}
+	if len(majors) == 0 {
+		return 0, fmt.Errorf("version selector %q: no versions available", s)
+	}
+
+	if offset < 0 {
+		for i := len(majors) - 1; i >= 0; i-- {
+			eol, ok := bd.endOfLife(Version{Components: []int{majors[i]}})
+			if !ok || eol.After(now) {
+				return majors[i], nil
+			}
+		}
+		return 0, fmt.Errorf("version selector %q: every version is past end of life", s)
+	}
+	if offset >= len(majors) {
+		return 0, fmt.Errorf("version selector %q: only %d major versions available", s, len(majors))
+	}
+	return majors[offset], nil
+}
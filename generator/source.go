@@ -0,0 +1,108 @@
+package useragent
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// DataSource supplies the browser data a Generator is built from. Load is
+// called once per Generator, and again on every reload.
+type DataSource interface {
+	Load() (*Config, error)
+}
+
+// DataSourceFunc adapts an ordinary function to a DataSource.
+type DataSourceFunc func() (*Config, error)
+
+// Load calls f().
+func (f DataSourceFunc) Load() (*Config, error) {
+	return f()
+}
+
+// Embedded returns the data compiled into this module (the default). It is
+// generated from browsers.yaml by go generate and needs no parsing; its
+// Config carries no version trees, only precompiled tables.
+func Embedded() DataSource {
+	return DataSourceFunc(func() (*Config, error) {
+		return embeddedConfig(), nil
+	})
+}
+
+// File reads data in the format of browsers.yaml from path on every load,
+// so a newer dataset can be shipped without recompiling.
+func File(path string) DataSource {
+	return fileSource(path)
+}
+
+type fileSource string
+
+func (f fileSource) Load() (*Config, error) {
+	content, err := os.ReadFile(string(f))
+	if err != nil {
+		return nil, err
+	}
+	config, err := decodeConfig(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", string(f), err)
+	}
+	return config, nil
+}
+
+// stamp identifies the file's current contents by size and modification
+// time, so Watch only reloads after a change.
+func (f fileSource) stamp() (string, error) {
+	info, err := os.Stat(string(f))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%d", info.Size(), info.ModTime().UnixNano()), nil
+}
+
+// Reader reads data in the format of browsers.yaml from r. The reader is
+// consumed by the first load; later loads, including concurrent ones,
+// return the same data or error.
+func Reader(r io.Reader) DataSource {
+	var (
+		once   sync.Once
+		config *Config
+		err    error
+	)
+	return DataSourceFunc(func() (*Config, error) {
+		once.Do(func() {
+			var content []byte
+			if content, err = io.ReadAll(r); err != nil {
+				return
+			}
+			config, err = decodeConfig(content)
+		})
+		return config, err
+	})
+}
+
+// FromConfig uses a caller-built configuration. The Generator does not
+// modify it, but it must not be modified while a load is in progress.
+func FromConfig(config *Config) DataSource {
+	return DataSourceFunc(func() (*Config, error) {
+		if config == nil {
+			return nil, fmt.Errorf("nil config")
+		}
+		return config, nil
+	})
+}
+
+// loadStore loads and validates the data of a source; strict also runs
+// ValidateData.
+func loadStore(src DataSource, strict bool) (*dataStore, error) {
+	config, err := src.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %w", err)
+	}
+	if strict {
+		if err := ValidateData(config); err != nil {
+			return nil, fmt.Errorf("invalid data: %w", err)
+		}
+	}
+	return buildStore(config)
+}
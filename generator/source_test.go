@@ -0,0 +1,150 @@
+package useragent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testData = `
+browsers:
+    chrome:
+        windows:
+            ua_template: "Chrome/{{version}}"
+            versions:
+                150:
+                    0:
+                        7500: [12]
+`
+
+func TestDataSource(t *testing.T) {
+	check := func(t *testing.T, src DataSource, want string) {
+		t.Helper()
+		g, err := NewWithSource(src)
+		if err != nil {
+			t.Fatalf("NewWithSource failed: %v", err)
+		}
+		res, err := g.Generate()
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.UserAgent != want {
+			t.Errorf("Expected %q, got %q", want, res.UserAgent)
+		}
+	}
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "browsers.yaml")
+		if err := os.WriteFile(path, []byte(testData), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		check(t, File(path), "Chrome/150.0.7500.12")
+
+		if _, err := NewWithSource(File(filepath.Join(t.TempDir(), "missing.yaml"))); err == nil {
+			t.Error("Expected error for missing file")
+		}
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		check(t, Reader(strings.NewReader(testData)), "Chrome/150.0.7500.12")
+
+		if _, err := NewWithSource(Reader(strings.NewReader("browsers: [1"))); err == nil {
+			t.Error("Expected error for malformed data")
+		}
+
+		// Concurrent loads read the reader once and share the result
+		src := Reader(strings.NewReader(testData))
+		configs := make([]*Config, 8)
+		var wg sync.WaitGroup
+		for i := range configs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				configs[i], _ = src.Load()
+			}(i)
+		}
+		wg.Wait()
+		for i, config := range configs {
+			if config == nil || config != configs[0] {
+				t.Fatalf("Load %d returned %p, want %p", i, config, configs[0])
+			}
+		}
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		config := &Config{Browsers: map[string]map[string]PlatformConfig{
+			"chrome": {"windows": {
+				UATemplate: "Win Chrome/{{version}}",
+				Versions:   map[int]interface{}{151: map[int]interface{}{0: map[int]interface{}{7600: []int{3}}}},
+			}},
+		}}
+		check(t, FromConfig(config), "Win Chrome/151.0.7600.3")
+
+		config.Browsers["chrome"]["windows"] = PlatformConfig{UATemplate: "x", Device: "watch"}
+		if _, err := NewWithSource(FromConfig(config)); err == nil {
+			t.Error("Expected validation error for unknown device class")
+		}
+	})
+
+	t.Run("Embedded", func(t *testing.T) {
+		g, err := NewWithSource(Embedded())
+		if err != nil {
+			t.Fatalf("NewWithSource failed: %v", err)
+		}
+		if _, err := g.Generate(); err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		check(t, Reader(strings.NewReader(`{
+			"schema_version": 1,
+			"browsers": {"chrome": {"windows": {
+				"ua_template": "Chrome/{{version}}",
+				"versions": {"150": {"0": {"7500": [12]}}}
+			}}}
+		}`)), "Chrome/150.0.7500.12")
+	})
+
+	t.Run("FlatBuilds", func(t *testing.T) {
+		yamlData := `
+browsers:
+    chrome:
+        windows:
+            ua_template: "Chrome/{{version}}"
+            builds:
+                - version: 150.0.7500.12
+                  released: "2026-03-03"
+                  channel: beta
+`
+		jsonData := `{"browsers": {"chrome": {"windows": {
+			"ua_template": "Chrome/{{version}}",
+			"builds": [{"version": "150.0.7500.12", "released": "2026-03-03", "channel": "beta"}]
+		}}}}`
+		for _, data := range []string{yamlData, jsonData} {
+			g, err := NewWithSource(Reader(strings.NewReader(data)))
+			if err != nil {
+				t.Fatalf("NewWithSource failed: %v", err)
+			}
+			candidates, err := g.Candidates(WithChannel("beta"))
+			if err != nil {
+				t.Fatalf("Candidates failed: %v", err)
+			}
+			if len(candidates) != 1 || candidates[0].Version.String() != "150.0.7500.12" || candidates[0].Released.Format(dateLayout) != "2026-03-03" {
+				t.Errorf("Unexpected candidates %+v", candidates)
+			}
+		}
+
+		if _, err := NewWithSource(Reader(strings.NewReader(strings.Replace(yamlData, "150.0.7500.12", "150.0.x", 1)))); err == nil {
+			t.Error("Expected error for malformed build version")
+		}
+	})
+
+	t.Run("SchemaVersion", func(t *testing.T) {
+		_, err := NewWithSource(Reader(strings.NewReader("schema_version: 99\n" + testData)))
+		if err == nil || !strings.Contains(err.Error(), "schema version 99") {
+			t.Errorf("Expected schema version error, got %v", err)
+		}
+	})
+}
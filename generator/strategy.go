@@ -0,0 +1,132 @@
+package useragent
+
+import (
+	"math"
+)
+
+// SelectionStrategy assigns selection weights to candidate versions.
+// Versions are passed sorted newest first and the returned slice must have
+// the same length. A version with a weight of zero or less is never picked.
+type SelectionStrategy interface {
+	Weights(versions []Version) []float64
+}
+
+// SelectionFunc adapts an ordinary function to a SelectionStrategy.
+type SelectionFunc func(versions []Version) []float64
+
+// Weights calls f(versions).
+func (f SelectionFunc) Weights(versions []Version) []float64 {
+	return f(versions)
+}
+
+// Uniform picks every version with equal probability.
+func Uniform() SelectionStrategy {
+	return SelectionFunc(func(versions []Version) []float64 {
+		weights := make([]float64, len(versions))
+		for i := range weights {
+			weights[i] = 1
+		}
+		return weights
+	})
+}
+
+// Linear favours newer versions linearly: of n versions the newest gets
+// weight n and the oldest weight 1. This is the default strategy.
+func Linear() SelectionStrategy {
+	return SelectionFunc(func(versions []Version) []float64 {
+		n := len(versions)
+		weights := make([]float64, n)
+		for i := range weights {
+			weights[i] = float64(n - i)
+		}
+		return weights
+	})
+}
+
+// ExponentialDecay favours newer versions exponentially: each version is
+// weighted factor times its next newer one. Factor should be in (0, 1);
+// 0.9 keeps a long tail, 0.5 concentrates on the newest builds.
+func ExponentialDecay(factor float64) SelectionStrategy {
+	return SelectionFunc(func(versions []Version) []float64 {
+		weights := make([]float64, len(versions))
+		for i := range weights {
+			weights[i] = math.Pow(factor, float64(i))
+		}
+		return weights
+	})
+}
+
+// LatestN picks uniformly among the n newest versions only.
+func LatestN(n int) SelectionStrategy {
+	return SelectionFunc(func(versions []Version) []float64 {
+		weights := make([]float64, len(versions))
+		for i := range weights {
+			if i < n {
+				weights[i] = 1
+			}
+		}
+		return weights
+	})
+}
+
+// defaultAdoptionShares approximates the share of users on the newest major
+// version and each of the ones before it, as seen in real Chrome traffic.
+var defaultAdoptionShares = []float64{0.55, 0.25, 0.08, 0.04, 0.02, 0.01}
+
+// AdoptionCurve weights versions by how many users run their major version:
+// shares[0] is the share of the newest major in the candidates, shares[1]
+// the one before it, and so on; older majors get nothing. Each major's share
+// is split evenly between its builds. Without arguments a curve modelled on
+// real Chrome traffic is used.
+func AdoptionCurve(shares ...float64) SelectionStrategy {
+	if len(shares) == 0 {
+		shares = defaultAdoptionShares
+	}
+	return SelectionFunc(func(versions []Version) []float64 {
+		// Versions are sorted newest first, so majors appear in descending order.
+		rank := make([]int, len(versions))
+		builds := make(map[int]int)
+		r := -1
+		for i, v := range versions {
+			if i == 0 || majorComponent(v) != majorComponent(versions[i-1]) {
+				r++
+			}
+			rank[i] = r
+			builds[r]++
+		}
+
+		weights := make([]float64, len(versions))
+		for i := range versions {
+			if rank[i] < len(shares) {
+				weights[i] = shares[rank[i]] / float64(builds[rank[i]])
+			}
+		}
+		return weights
+	})
+}
+
+// ExplicitWeights uses caller-provided weights. Keys are versions or version
+// prefixes ("134" covers every 134.x build); the most specific key wins and
+// versions without a matching key are never picked.
+func ExplicitWeights(weights map[string]float64) SelectionStrategy {
+	return SelectionFunc(func(versions []Version) []float64 {
+		out := make([]float64, len(versions))
+		for i, v := range versions {
+			for n := len(v.Components); n > 0; n-- {
+				prefix := Version{Components: v.Components[:n]}
+				if w, ok := weights[prefix.String()]; ok {
+					out[i] = w
+					break
+				}
+			}
+		}
+		return out
+	})
+}
+
+func majorComponent(v Version) int {
+	if len(v.Components) == 0 {
+		return 0
+	}
+	return v.Components[0]
+}
@@ -0,0 +1,272 @@
+package useragent
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// User-Agent templates are plain text with placeholders in double braces.
+// Conditionals select text by the value of a placeholder:
+//
+//	{{if device_model}}{{device_model}}{{else}}K{{end}}
+//	{{if arch == "arm"}}aarch64{{else}}x86_64{{end}}
+//
+// A bare condition holds when the value is not empty; == and != compare it
+// with a quoted string. Conditionals nest. Templates are parsed when the data
+// is loaded, so a malformed template, an unclosed {{ or an unknown
+// placeholder fails the load.
+var templatePlaceholders = map[string]string{
+	"version":         "full version, e.g. 134.0.6998.35",
+	"major":           "major version, e.g. 134",
+	"reduced_version": "major version followed by zeros, e.g. 134.0.0.0",
+	"os_version":      "marketing OS version of the variant, e.g. 11",
+	"arch":            "Sec-CH-UA-Arch value of the variant, e.g. x86 or arm",
+	"bitness":         "Sec-CH-UA-Bitness value of the variant, e.g. 64",
+	"arch_token":      "architecture as written in UA strings, e.g. Win64; x64 or aarch64",
+	"device_model":    "device model of the variant, empty on desktop",
+	"webkit_version":  "WebKit version, 537.36 for Chromium browsers",
+	"engine_version":  "engine version from the metadata, else the browser version (Chromium) or major.0 (Firefox)",
+}
+
+// uaTemplate is a parsed User-Agent template.
+type uaTemplate struct {
+	source string
+	nodes  []templateNode
+}
+
+// templateNode is literal text, a placeholder or a conditional.
+type templateNode struct {
+	text string // Literal text when name is empty
+	name string // Placeholder, or the value tested by a conditional
+
+	cond      bool   // Conditional node
+	op        string // "", "==" or "!="
+	value     string // Compared value for == and !=
+	then, els []templateNode
+}
+
+var templateTagRe = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+
+var templateCondRe = regexp.MustCompile(`^if\s+([a-z_]+)(?:\s*(==|!=)\s*("(?:[^"\\]|\\.)*"))?$`)
+
+// parseTemplate parses a User-Agent template.
+func parseTemplate(source string) (*uaTemplate, error) {
+	p := &templateParser{source: source}
+	nodes, _, err := p.parse(0)
+	if err != nil {
+		return nil, err
+	}
+	return &uaTemplate{source: source, nodes: nodes}, nil
+}
+
+type templateParser struct {
+	source string
+	pos    int
+}
+
+// parse parses nodes up to the end of the template or an {{else}} or
+// {{end}} tag, which it returns, at the given nesting depth.
+func (p *templateParser) parse(depth int) ([]templateNode, string, error) {
+	var nodes []templateNode
+	for p.pos < len(p.source) {
+		loc := templateTagRe.FindStringSubmatchIndex(p.source[p.pos:])
+		if loc == nil {
+			if i := strings.Index(p.source[p.pos:], "{{"); i >= 0 {
+				return nil, "", fmt.Errorf("template %q: unclosed {{ at offset %d", p.source, p.pos+i)
+			}
+			nodes = append(nodes, templateNode{text: p.source[p.pos:]})
+			p.pos = len(p.source)
+			break
+		}
+		if loc[0] > 0 {
+			nodes = append(nodes, templateNode{text: p.source[p.pos : p.pos+loc[0]]})
+		}
+		tag := p.source[p.pos+loc[2] : p.pos+loc[3]]
+		if strings.Contains(tag, "{{") {
+			// "{{a {{version}}" matches as one tag from the first {{
+			return nil, "", fmt.Errorf("template %q: unclosed {{ at offset %d", p.source, p.pos+loc[0])
+		}
+		p.pos += loc[1]
+
+		switch {
+		case tag == "else" || tag == "end":
+			if depth == 0 {
+				return nil, "", fmt.Errorf("template %q: {{%s}} without {{if}}", p.source, tag)
+			}
+			return nodes, tag, nil
+		case strings.HasPrefix(tag, "if ") || tag == "if":
+			node, err := p.parseIf(tag, depth)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, node)
+		default:
+			if _, ok := templatePlaceholders[tag]; !ok {
+				return nil, "", fmt.Errorf("template %q: unknown placeholder {{%s}}", p.source, tag)
+			}
+			nodes = append(nodes, templateNode{name: tag})
+		}
+	}
+	if depth > 0 {
+		return nil, "", fmt.Errorf("template %q: {{if}} without {{end}}", p.source)
+	}
+	return nodes, "", nil
+}
+
+func (p *templateParser) parseIf(tag string, depth int) (templateNode, error) {
+	m := templateCondRe.FindStringSubmatch(tag)
+	if m == nil {
+		return templateNode{}, fmt.Errorf("template %q: malformed condition {{%s}}", p.source, tag)
+	}
+	if _, ok := templatePlaceholders[m[1]]; !ok {
+		return templateNode{}, fmt.Errorf("template %q: unknown placeholder %q in {{%s}}", p.source, m[1], tag)
+	}
+	node := templateNode{name: m[1], cond: true, op: m[2]}
+	if m[3] != "" {
+		value, err := strconv.Unquote(m[3])
+		if err != nil {
+			return templateNode{}, fmt.Errorf("template %q: malformed string in {{%s}}", p.source, tag)
+		}
+		node.value = value
+	}
+
+	then, end, err := p.parse(depth + 1)
+	if err != nil {
+		return templateNode{}, err
+	}
+	node.then = then
+	if end == "else" {
+		els, end, err := p.parse(depth + 1)
+		if err != nil {
+			return templateNode{}, err
+		}
+		if end != "end" {
+			return templateNode{}, fmt.Errorf("template %q: {{else}} followed by {{else}}", p.source)
+		}
+		node.els = els
+	}
+	return node, nil
+}
+
+// uses reports whether the template references a placeholder, also in
+// conditions.
+func (t *uaTemplate) uses(name string) bool {
+	var walk func([]templateNode) bool
+	walk = func(nodes []templateNode) bool {
+		for _, n := range nodes {
+			if n.name == name || walk(n.then) || walk(n.els) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(t.nodes)
+}
+
+// execute renders the template with the values of a candidate.
+func (t *uaTemplate) execute(c Candidate) string {
+	var b strings.Builder
+	executeNodes(&b, t.nodes, templateValues{c})
+	return b.String()
+}
+
+func executeNodes(b *strings.Builder, nodes []templateNode, values templateValues) {
+	for _, n := range nodes {
+		switch {
+		case n.cond:
+			value := values.lookup(n.name)
+			var holds bool
+			switch n.op {
+			case "==":
+				holds = value == n.value
+			case "!=":
+				holds = value != n.value
+			default:
+				holds = value != ""
+			}
+			if holds {
+				executeNodes(b, n.then, values)
+			} else {
+				executeNodes(b, n.els, values)
+			}
+		case n.name != "":
+			b.WriteString(values.lookup(n.name))
+		default:
+			b.WriteString(n.text)
+		}
+	}
+}
+
+// templateValues computes placeholder values from a candidate.
+type templateValues struct {
+	c Candidate
+}
+
+func (v templateValues) lookup(name string) string {
+	c := v.c
+	major := 0
+	if len(c.Version.Components) > 0 {
+		major = c.Version.Components[0]
+	}
+
+	switch name {
+	case "version":
+		return c.Version.String()
+	case "major":
+		return strconv.Itoa(major)
+	case "reduced_version":
+		return fmt.Sprintf("%d.0.0.0", major)
+	case "os_version":
+		return c.OSVersion
+	case "arch":
+		return c.Architecture
+	case "bitness":
+		return c.Bitness
+	case "arch_token":
+		return archToken(c.OS, c.Architecture, c.Bitness)
+	case "device_model":
+		return c.Model
+	case "webkit_version":
+		if c.Browser == Safari || c.OS == IOS {
+			return firstNonEmpty(c.Engine, "605.1.15")
+		}
+		return "537.36"
+	case "engine_version":
+		if c.Engine != "" {
+			return c.Engine
+		}
+		switch {
+		case vendorBrand(c.Browser) != "":
+			return c.Version.String()
+		case c.Browser == Firefox:
+			return fmt.Sprintf("%d.0", major)
+		}
+	}
+	return ""
+}
+
+// archToken returns the architecture as browsers write it in the platform
+// part of the User-Agent.
+func archToken(os OSName, arch, bitness string) string {
+	arm := arch == "arm"
+	is64 := bitness != "32"
+	switch os {
+	case Windows:
+		return "Win64; x64" // Frozen in reduced UAs, on ARM and 32-bit too
+	case Linux, ChromeOS:
+		switch {
+		case arm && is64:
+			return "aarch64"
+		case arm:
+			return "armv7l"
+		case is64:
+			return "x86_64"
+		}
+		return "i686"
+	case MacOS:
+		return "Intel" // Frozen on Apple silicon too
+	}
+	return ""
+}
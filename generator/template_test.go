@@ -0,0 +1,86 @@
+package useragent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	desktop := Candidate{
+		Browser:      Chrome,
+		OS:           Windows,
+		Version:      Version{Components: []int{134, 0, 6998, 35}},
+		OSVersion:    "11",
+		Architecture: "x86",
+		Bitness:      "64",
+	}
+	armDesktop := desktop
+	armDesktop.Architecture = "arm"
+	phone := Candidate{
+		Browser: Chrome,
+		OS:      Android,
+		Version: Version{Components: []int{134, 0, 6998, 35}},
+		Model:   "Pixel 8",
+	}
+
+	t.Run("Execute", func(t *testing.T) {
+		tests := []struct {
+			template string
+			c        Candidate
+			want     string
+		}{
+			{"Chrome/{{version}}", desktop, "Chrome/134.0.6998.35"},
+			{"Chrome/{{ reduced_version }} ({{major}})", desktop, "Chrome/134.0.0.0 (134)"},
+			{"(Windows NT 10.0; {{arch_token}})", desktop, "(Windows NT 10.0; Win64; x64)"},
+			{"(Windows NT 10.0; {{arch_token}})", armDesktop, "(Windows NT 10.0; Win64; x64)"},
+			{"AppleWebKit/{{webkit_version}} Blink/{{engine_version}}", desktop, "AppleWebKit/537.36 Blink/134.0.6998.35"},
+			{"Android; {{if device_model}}{{device_model}}{{else}}K{{end}}", phone, "Android; Pixel 8"},
+			{"Android; {{if device_model}}{{device_model}}{{else}}K{{end}}", desktop, "Android; K"},
+			{`{{if os_version == "11"}}Win11{{if arch != "arm"}} x64{{end}}{{else}}Win10{{end}}`, desktop, "Win11 x64"},
+			{`{{if os_version != "11"}}old{{end}}new`, desktop, "new"},
+		}
+		for _, tt := range tests {
+			tmpl, err := parseTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("parseTemplate(%q) failed: %v", tt.template, err)
+			}
+			if got := tmpl.execute(tt.c); got != tt.want {
+				t.Errorf("%q rendered %q, want %q", tt.template, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("ParseErrors", func(t *testing.T) {
+		tests := map[string]string{
+			"Chrome/{{ver}}":                        "unknown placeholder",
+			"{{if model}}x{{end}}":                  "unknown placeholder",
+			"{{if device_model}}x":                  "without {{end}}",
+			"x{{end}}":                              "without {{if}}",
+			"{{if device_model}}a{{else}}b{{else}}": "followed by {{else}}",
+			"{{if device_model = \"x\"}}a{{end}}":   "malformed condition",
+			"Chrome/{{version":                      "unclosed {{ at offset 7",
+			"Chrome/{{ Safari/{{webkit_version}}":   "unclosed {{ at offset 7",
+			"{{if device_model}}{{x{{end}}":         "unclosed {{ at offset 19",
+		}
+		for template, want := range tests {
+			if _, err := parseTemplate(template); err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("parseTemplate(%q) = %v, want error containing %q", template, err, want)
+			}
+		}
+	})
+
+	t.Run("Generate", func(t *testing.T) {
+		data := strings.Replace(testData, `"Chrome/{{version}}"`, `"Chrome/{{reduced_version}} ({{arch_token}})"`, 1)
+		g, err := NewWithSource(Reader(strings.NewReader(data)))
+		if err != nil {
+			t.Fatalf("NewWithSource failed: %v", err)
+		}
+		res, err := g.Generate()
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if res.UserAgent != "Chrome/150.0.0.0 (Win64; x64)" {
+			t.Errorf("Unexpected User-Agent %q", res.UserAgent)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package useragent
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -11,17 +13,25 @@ type BrowserName string
 // OSName represents the operating system name (e.g., "windows", "linux").
 type OSName string
 
+// DeviceClass represents the kind of device (e.g., "desktop", "mobile").
+type DeviceClass string
+
 const (
 	Chrome  BrowserName = "chrome"
 	Firefox BrowserName = "firefox"
 	Safari  BrowserName = "safari"
 	Edge    BrowserName = "edge"
 
-	Windows OSName = "windows"
-	Linux   OSName = "linux"
-	MacOS   OSName = "macos"
-	Android OSName = "android"
-	IOS     OSName = "ios"
+	Windows  OSName = "windows"
+	Linux    OSName = "linux"
+	MacOS    OSName = "macos"
+	Android  OSName = "android"
+	IOS      OSName = "ios"
+	ChromeOS OSName = "chromeos"
+
+	Desktop DeviceClass = "desktop"
+	Mobile  DeviceClass = "mobile"
+	Tablet  DeviceClass = "tablet"
 )
 
 // Version represents a semantic version with variable number of components.
@@ -37,6 +47,44 @@ func (v Version) String() string {
 	return strings.Join(parts, ".")
 }
 
+// clone returns a copy of v that does not share Components with it.
+func (v Version) clone() Version {
+	return Version{Components: append([]int(nil), v.Components...)}
+}
+
+// ParseVersion parses a dotted version string like "133.0.6943.53".
+// Unlike the lenient parsing used by WithMinVersion, every component must be a non-negative integer.
+func ParseVersion(s string) (Version, error) {
+	if s == "" {
+		return Version{}, fmt.Errorf("empty version")
+	}
+	parts := strings.Split(s, ".")
+	components := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || strings.HasPrefix(p, "+") {
+			return Version{}, fmt.Errorf("invalid version %q: component %q is not a number", s, p)
+		}
+		components[i] = n
+	}
+	return Version{Components: components}, nil
+}
+
+// MarshalText implements encoding.TextMarshaler so versions persist as "133.0.6943.53".
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := ParseVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
 // Compare returns -1 if v < other, 1 if v > other, 0 if equal.
 func (v Version) Compare(other Version) int {
 	len1 := len(v.Components)
@@ -66,19 +114,136 @@ func (v Version) Compare(other Version) int {
 	return 0
 }
 
-// Config represents the top-level structure of the YAML file.
+// CurrentSchemaVersion is the newest data schema this package understands.
+// Data files declare theirs in schema_version; files without one are
+// treated as version 1.
+const CurrentSchemaVersion = 1
+
+// Config represents the top-level structure of the data file. It can be
+// written as YAML or JSON with the same field names.
 type Config struct {
-	Browsers map[string]map[string]PlatformConfig `yaml:"browsers"`
+	// SchemaVersion is the schema the data was written for. Loading data
+	// with a newer schema fails instead of misreading it.
+	SchemaVersion int                                  `yaml:"schema_version,omitempty" json:"schema_version,omitempty"`
+	Browsers      map[string]map[string]PlatformConfig `yaml:"browsers" json:"browsers"`
+	// MarketShare is the default population used by WithMarketShare.
+	MarketShare []MarketShare `yaml:"market_share,omitempty" json:"market_share,omitempty"`
+	// Denylist lists builds per browser that are never generated, such as
+	// pulled releases or builds flagged by detection vendors.
+	Denylist map[string][]DeniedBuild `yaml:"denylist,omitempty" json:"denylist,omitempty"`
+
+	// compiled holds pre-parsed versions keyed by "browser/os", newest
+	// first; it replaces Versions and Builds for those platforms. Only the
+	// generated tables of the embedded data set it.
+	compiled map[string][]Version
+	// locate returns the position of a path in the decoded file, for
+	// ValidateData; nil when positions are unknown.
+	locate func(path []string) (line, column int)
+	// decodeErrs lists values the decoder skipped. ValidateData reports
+	// them and buildStore refuses the config.
+	decodeErrs DataErrors
+}
+
+// DeniedBuild is a denylist entry.
+type DeniedBuild struct {
+	Version string `yaml:"version" json:"version"`                   // Version or version prefix
+	OS      OSName `yaml:"os,omitempty" json:"os,omitempty"`         // Empty applies to every OS
+	Reason  string `yaml:"reason,omitempty" json:"reason,omitempty"` // Why the build is denied
+}
+
+// MarketShare is the relative share of traffic for a browser/OS/device combination.
+// Shares are weights and need not add up to 1.
+type MarketShare struct {
+	Browser BrowserName `yaml:"browser" json:"browser"`
+	OS      OSName      `yaml:"os" json:"os"`
+	Device  DeviceClass `yaml:"device,omitempty" json:"device,omitempty"` // Empty matches the platform's device class
+	Share   float64     `yaml:"share" json:"share"`
 }
 
 // PlatformConfig holds the template and version data for a specific OS.
 type PlatformConfig struct {
-	UATemplate string `yaml:"ua_template"`
+	UATemplate string `yaml:"ua_template" json:"ua_template"`
+	// Device is the device class of the platform (default: desktop).
+	Device DeviceClass `yaml:"device,omitempty" json:"device,omitempty"`
+	// VersionsFrom names another platform of the same browser whose versions
+	// and metadata this platform shares, e.g. "windows".
+	VersionsFrom string `yaml:"versions_from,omitempty" json:"versions_from,omitempty"`
+	// Variants lists the OS versions, architectures and device models the
+	// platform is generated for. Without variants a single default is used.
+	Variants []PlatformVariant `yaml:"variants,omitempty" json:"variants,omitempty"`
 	// Versions is a nested map structure.
 	// We use map[int]interface{} to support variable depth.
 	// The value can be:
 	// - map[int]interface{} (next level)
 	// - []int (leaf list of patches)
 	// - nil (end of version)
-	Versions map[int]interface{} `yaml:"versions"`
+	Versions map[int]interface{} `yaml:"versions,omitempty" json:"versions,omitempty"`
+	// Builds is a flat alternative to Versions that is easier to produce
+	// from other tooling: a plain list of versions with their metadata.
+	// Both may be used together.
+	Builds []Build `yaml:"builds,omitempty" json:"builds,omitempty"`
+	// Metadata attaches data to versions, keyed by a version or version prefix
+	// ("133" applies to every 133.x build unless a more specific key exists).
+	Metadata map[string]VersionMeta `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	// Overrides change the template and header rules of version ranges, for
+	// formats that changed over time such as Chrome's UA reduction.
+	Overrides []Override `yaml:"overrides,omitempty" json:"overrides,omitempty"`
+}
+
+// AllVersions returns the versions of the tree and the flat build list,
+// newest first and without duplicates.
+func (p PlatformConfig) AllVersions() ([]Version, error) {
+	versions := parseVersions([]int{}, p.Versions)
+	for _, b := range p.Builds {
+		v, err := ParseVersion(b.Version)
+		if err != nil {
+			return nil, fmt.Errorf("builds: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	versions = dedupeVersions(versions)
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) > 0
+	})
+	return versions, nil
+}
+
+// PlatformVariant is one OS version, architecture and device model
+// combination of a platform. Empty fields take the platform's defaults.
+type PlatformVariant struct {
+	OSVersion       string  `yaml:"os_version,omitempty" json:"os_version,omitempty"`             // Marketing version, e.g. "11"
+	PlatformVersion string  `yaml:"platform_version,omitempty" json:"platform_version,omitempty"` // Sec-CH-UA-Platform-Version value
+	Architecture    string  `yaml:"architecture,omitempty" json:"architecture,omitempty"`         // Sec-CH-UA-Arch value, e.g. "x86" or "arm"
+	Bitness         string  `yaml:"bitness,omitempty" json:"bitness,omitempty"`
+	Model           string  `yaml:"model,omitempty" json:"model,omitempty"`             // Device model, mobile platforms only
+	FormFactor      string  `yaml:"form_factor,omitempty" json:"form_factor,omitempty"` // Default derives from the device class
+	Weight          float64 `yaml:"weight,omitempty" json:"weight,omitempty"`           // Relative weight, default 1
+}
+
+// Build is an entry of the flat version list.
+type Build struct {
+	Version     string `yaml:"version" json:"version"`
+	VersionMeta `yaml:",inline"`
+}
+
+// VersionMeta holds metadata for a version or version prefix.
+type VersionMeta struct {
+	Released string `yaml:"released,omitempty" json:"released,omitempty"` // Stable release date, YYYY-MM-DD
+	EOL      string `yaml:"eol,omitempty" json:"eol,omitempty"`           // Date updates stopped, YYYY-MM-DD
+	Channel  string `yaml:"channel,omitempty" json:"channel,omitempty"`   // Release channel, default "stable"
+	Engine   string `yaml:"engine,omitempty" json:"engine,omitempty"`     // Rendering engine version, e.g. "605.1.15"
+}
+
+// Override changes how the versions matching a constraint are rendered.
+// Empty fields keep the platform's settings.
+type Override struct {
+	// Versions is a constraint such as "<110" (see Constraint); relative
+	// selectors are not allowed.
+	Versions   string `yaml:"versions" json:"versions"`
+	UATemplate string `yaml:"ua_template,omitempty" json:"ua_template,omitempty"`
+	// ClientHints set to false drops the Sec-CH-UA-* headers and hints, for
+	// versions that predate them.
+	ClientHints *bool `yaml:"client_hints,omitempty" json:"client_hints,omitempty"`
+	// Headers are set on every result; an empty value removes the header.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
 }
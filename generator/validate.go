@@ -0,0 +1,587 @@
+package useragent
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severity ranks how serious a validation finding is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Finding codes reported by Validate.
+const (
+	CodeMissingUserAgent   = "missing-user-agent"
+	CodeHintUnsupported    = "hint-unsupported"
+	CodeInvalidValue       = "invalid-value"
+	CodeMajorMismatch      = "major-mismatch"
+	CodeUnknownBrand       = "unknown-brand"
+	CodeMissingBrand       = "missing-brand"
+	CodeDuplicateBrand     = "duplicate-brand"
+	CodeGreaseCount        = "grease-count"
+	CodeGreaseFormat       = "grease-format"
+	CodeAutomationBrand    = "automation-brand"
+	CodeBrandListMismatch  = "brand-list-mismatch"
+	CodeFullVersionInvalid = "full-version-invalid"
+	CodeFullVersionDiffers = "full-version-mismatch"
+	CodeUANotReduced       = "ua-not-reduced"
+	CodePlatformMismatch   = "platform-mismatch"
+	CodeMobileMismatch     = "mobile-mismatch"
+	CodeBitnessMismatch    = "bitness-mismatch"
+	CodeWow64Mismatch      = "wow64-mismatch"
+	CodeFormFactorMismatch = "form-factor-mismatch"
+	CodeModelOnDesktop     = "model-on-desktop"
+)
+
+// Finding describes a single inconsistency detected by Validate.
+type Finding struct {
+	Severity Severity
+	Code     string // Stable identifier, one of the Code* constants
+	Header   string // Header the finding is attached to
+	Message  string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s (%s): %s", f.Severity, f.Code, f.Header, f.Message)
+}
+
+// HasErrors reports whether any of the findings has error severity.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity >= SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// firstClientHintsMajor is the first Chrome release that sent Sec-CH-UA by default.
+	firstClientHintsMajor = 89
+	// firstReducedMajor is the first Chrome release that fully reduced the desktop UA string.
+	firstReducedMajor = 110
+	// firstGreaseV2Major is the first Chrome release with the current GREASE
+	// algorithm. Older releases prefix the brand with a space, ";" or "." and
+	// always send version 99; newer ones send no prefix and version 8, 24 or 99.
+	firstGreaseV2Major = 105
+)
+
+// greaseBrandRe matches both GREASE formats, e.g. " Not A;Brand" and "Not(A:Brand".
+var greaseBrandRe = regexp.MustCompile(`^[ ;.]?Not[^A-Za-z0-9]A[^A-Za-z0-9]Brand$`)
+
+// greaseVersions lists the versions the current GREASE algorithm sends.
+var greaseVersions = map[string]bool{"8": true, "24": true, "99": true}
+
+// Validate checks a header set for contradictions between the User-Agent
+// string and the Client Hints headers. Header names are matched
+// case-insensitively, so captured traffic can be passed as-is.
+func Validate(headers map[string]string) []Finding {
+	v := &validator{headers: make(map[string]string, len(headers))}
+	for k, val := range headers {
+		v.headers[strings.ToLower(k)] = val
+	}
+
+	ua, ok := v.get("User-Agent")
+	if !ok || ua == "" {
+		v.add(SeverityError, CodeMissingUserAgent, "User-Agent", "User-Agent header is missing")
+		return v.findings
+	}
+	v.ua = parseUA(ua)
+
+	if !v.checkHintSupport() {
+		return v.findings
+	}
+	brands := v.checkBrands()
+	v.checkFullVersions(brands)
+	v.checkReduction()
+	v.checkPlatform()
+	v.checkMobile()
+	v.checkArchitecture()
+
+	return v.findings
+}
+
+type validator struct {
+	headers  map[string]string
+	ua       uaInfo
+	findings []Finding
+}
+
+func (v *validator) get(name string) (string, bool) {
+	val, ok := v.headers[strings.ToLower(name)]
+	return val, ok
+}
+
+func (v *validator) add(sev Severity, code, header, format string, args ...interface{}) {
+	v.findings = append(v.findings, Finding{
+		Severity: sev,
+		Code:     code,
+		Header:   header,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// hintNames lists the Client Hints headers in the order they are reported.
+var hintNames = []string{
+	"Sec-CH-UA",
+	"Sec-CH-UA-Full-Version",
+	"Sec-CH-UA-Full-Version-List",
+	"Sec-CH-UA-Platform",
+	"Sec-CH-UA-Platform-Version",
+	"Sec-CH-UA-Mobile",
+	"Sec-CH-UA-Bitness",
+	"Sec-CH-UA-Arch",
+	"Sec-CH-UA-Model",
+	"Sec-CH-UA-Wow64",
+	"Sec-CH-UA-Form-Factors",
+}
+
+// checkHintSupport flags hints sent by browsers that never send them.
+// It returns false when there is nothing more to check.
+func (v *validator) checkHintSupport() bool {
+	var present []string
+	for _, name := range hintNames {
+		if _, ok := v.get(name); ok {
+			present = append(present, name)
+		}
+	}
+	if len(present) == 0 {
+		return false
+	}
+
+	reason := ""
+	switch {
+	case !v.ua.chromium():
+		reason = "browser does not implement User-Agent Client Hints"
+		if v.ua.browser != "" {
+			reason = fmt.Sprintf("%s does not implement User-Agent Client Hints", v.ua.browser)
+		}
+	case v.ua.major() < firstClientHintsMajor:
+		reason = fmt.Sprintf("Client Hints were not sent before version %d", firstClientHintsMajor)
+	}
+	if reason == "" {
+		return true
+	}
+	for _, name := range present {
+		v.add(SeverityError, CodeHintUnsupported, name, "%s", reason)
+	}
+	return false
+}
+
+// checkBrands validates Sec-CH-UA against the browser and version in the UA string.
+func (v *validator) checkBrands() []brandVersion {
+	raw, ok := v.get("Sec-CH-UA")
+	if !ok {
+		return nil
+	}
+	brands, err := parseBrandList(raw)
+	if err != nil {
+		v.add(SeverityError, CodeInvalidValue, "Sec-CH-UA", "%v", err)
+		return nil
+	}
+
+	vendor := vendorBrand(v.ua.browser)
+	seen := make(map[string]bool)
+	grease := 0
+	hasChromium, hasVendor, fork := false, false, false
+
+	for _, b := range brands {
+		if seen[b.brand] {
+			v.add(SeverityError, CodeDuplicateBrand, "Sec-CH-UA", "brand %q is listed more than once", b.brand)
+			continue
+		}
+		seen[b.brand] = true
+
+		switch {
+		case greaseBrandRe.MatchString(b.brand):
+			grease++
+			v.checkGrease(b)
+			continue
+		case b.brand == "Chromium":
+			hasChromium = true
+		case b.brand == vendor:
+			hasVendor = true
+		case b.brand == "HeadlessChrome":
+			v.add(SeverityWarning, CodeAutomationBrand, "Sec-CH-UA", "brand %q is only sent by headless Chrome", b.brand)
+		case isVendorBrand(b.brand):
+			v.add(SeverityError, CodeUnknownBrand, "Sec-CH-UA", "brand %q does not exist for %s", b.brand, v.ua.browserLabel())
+			continue
+		default:
+			// Forks like Opera or Brave send their own brand and version
+			// with a Chrome UA string.
+			fork = true
+			v.add(SeverityWarning, CodeUnknownBrand, "Sec-CH-UA", "brand %q is unknown, possibly a Chromium fork", b.brand)
+			continue
+		}
+
+		if major := majorOf(b.version); major != v.ua.major() {
+			v.add(SeverityError, CodeMajorMismatch, "Sec-CH-UA",
+				"brand %q has version %s but User-Agent major is %d", b.brand, b.version, v.ua.major())
+		}
+	}
+
+	if !hasChromium {
+		v.add(SeverityError, CodeMissingBrand, "Sec-CH-UA", "brand \"Chromium\" is missing")
+	}
+	if vendor != "" && !hasVendor && !fork {
+		v.add(SeverityWarning, CodeMissingBrand, "Sec-CH-UA", "brand %q is missing", vendor)
+	}
+	switch {
+	case grease == 0:
+		v.add(SeverityWarning, CodeGreaseCount, "Sec-CH-UA", "no GREASE brand present")
+	case grease > 1:
+		v.add(SeverityError, CodeGreaseCount, "Sec-CH-UA", "%d GREASE brands present, browsers send exactly one", grease)
+	}
+
+	return brands
+}
+
+// checkGrease flags a GREASE brand in the format of another browser version.
+func (v *validator) checkGrease(b brandVersion) {
+	legacy := !strings.HasPrefix(b.brand, "Not")
+	switch major := v.ua.major(); {
+	case major < firstGreaseV2Major && (!legacy || b.version != "99"):
+		v.add(SeverityWarning, CodeGreaseFormat, "Sec-CH-UA",
+			"GREASE brand %q;v=%q was not sent before version %d", b.brand, b.version, firstGreaseV2Major)
+	case major >= firstGreaseV2Major && (legacy || !greaseVersions[b.version]):
+		v.add(SeverityWarning, CodeGreaseFormat, "Sec-CH-UA",
+			"GREASE brand %q;v=%q was not sent after version %d", b.brand, b.version, firstGreaseV2Major-1)
+	}
+}
+
+// checkFullVersions validates Sec-CH-UA-Full-Version-List and Sec-CH-UA-Full-Version.
+func (v *validator) checkFullVersions(brands []brandVersion) {
+	if raw, ok := v.get("Sec-CH-UA-Full-Version-List"); ok {
+		list, err := parseBrandList(raw)
+		if err != nil {
+			v.add(SeverityError, CodeInvalidValue, "Sec-CH-UA-Full-Version-List", "%v", err)
+		} else {
+			v.checkFullVersionList(list, brands)
+		}
+	}
+
+	if raw, ok := v.get("Sec-CH-UA-Full-Version"); ok {
+		v.checkFullVersion("Sec-CH-UA-Full-Version", "", unquote(raw))
+	}
+}
+
+func (v *validator) checkFullVersionList(list, brands []brandVersion) {
+	const header = "Sec-CH-UA-Full-Version-List"
+
+	if brands != nil {
+		names := make(map[string]bool, len(brands))
+		for _, b := range brands {
+			names[b.brand] = true
+		}
+		for _, b := range list {
+			if !names[b.brand] {
+				v.add(SeverityError, CodeBrandListMismatch, header, "brand %q is not present in Sec-CH-UA", b.brand)
+			}
+			delete(names, b.brand)
+		}
+		for _, b := range brands {
+			if names[b.brand] {
+				v.add(SeverityError, CodeBrandListMismatch, header, "brand %q from Sec-CH-UA is missing", b.brand)
+			}
+		}
+	}
+
+	for _, b := range list {
+		if greaseBrandRe.MatchString(b.brand) {
+			continue
+		}
+		v.checkFullVersion(header, b.brand, b.version)
+	}
+}
+
+func (v *validator) checkFullVersion(header, brand, full string) {
+	label := "full version"
+	if brand != "" {
+		label = fmt.Sprintf("brand %q", brand)
+	}
+
+	parsed, err := ParseVersion(full)
+	if err != nil || len(parsed.Components) != 4 {
+		v.add(SeverityError, CodeFullVersionInvalid, header, "%s has malformed version %q", label, full)
+		return
+	}
+	if parsed.Components[0] != v.ua.major() {
+		v.add(SeverityError, CodeMajorMismatch, header,
+			"%s has version %s but User-Agent major is %d", label, full, v.ua.major())
+		return
+	}
+	// An unreduced UA string exposes the full version, so both must agree.
+	if !v.ua.reduced() && parsed.Compare(v.ua.version) != 0 {
+		v.add(SeverityError, CodeFullVersionDiffers, header,
+			"%s has version %s but User-Agent reports %s", label, full, v.ua.version)
+	}
+}
+
+// checkReduction flags UA strings that expose build details the browser no longer sends.
+func (v *validator) checkReduction() {
+	if v.ua.major() >= firstReducedMajor && !v.ua.reduced() {
+		v.add(SeverityWarning, CodeUANotReduced, "User-Agent",
+			"version %s is not reduced, %s %d+ sends %d.0.0.0", v.ua.version, v.ua.browserLabel(), firstReducedMajor, v.ua.major())
+	}
+}
+
+func (v *validator) checkPlatform() {
+	raw, ok := v.get("Sec-CH-UA-Platform")
+	if !ok || v.ua.os == "" {
+		return
+	}
+	got := unquote(raw)
+	if want := platformProfiles[v.ua.os].hint; want != "" && got != want {
+		v.add(SeverityError, CodePlatformMismatch, "Sec-CH-UA-Platform",
+			"platform %q contradicts User-Agent OS, expected %q", got, want)
+	}
+}
+
+func (v *validator) checkMobile() {
+	raw, ok := v.get("Sec-CH-UA-Mobile")
+	mobile := v.ua.mobile
+	if ok {
+		switch strings.TrimSpace(raw) {
+		case "?1":
+			mobile = true
+		case "?0":
+			mobile = false
+		default:
+			v.add(SeverityError, CodeInvalidValue, "Sec-CH-UA-Mobile", "value %q is not a structured boolean", raw)
+			return
+		}
+		if mobile != v.ua.mobile {
+			v.add(SeverityError, CodeMobileMismatch, "Sec-CH-UA-Mobile",
+				"mobile hint is %s but User-Agent mobile token is %t", raw, v.ua.mobile)
+		}
+	}
+
+	if raw, ok := v.get("Sec-CH-UA-Form-Factors"); ok && mobile {
+		if strings.Contains(raw, `"Desktop"`) && !strings.Contains(raw, `"Mobile"`) {
+			v.add(SeverityWarning, CodeFormFactorMismatch, "Sec-CH-UA-Form-Factors",
+				"form factors %s contradict a mobile browser", raw)
+		}
+	}
+	if raw, ok := v.get("Sec-CH-UA-Model"); ok && !mobile && unquote(raw) != "" {
+		v.add(SeverityWarning, CodeModelOnDesktop, "Sec-CH-UA-Model",
+			"desktop browsers send an empty model, got %s", raw)
+	}
+}
+
+func (v *validator) checkArchitecture() {
+	if v.ua.os != Windows {
+		return
+	}
+	if raw, ok := v.get("Sec-CH-UA-Bitness"); ok && v.ua.win64 && unquote(raw) != "64" {
+		v.add(SeverityError, CodeBitnessMismatch, "Sec-CH-UA-Bitness",
+			"bitness %s contradicts the Win64 User-Agent token", raw)
+	}
+	if raw, ok := v.get("Sec-CH-UA-Wow64"); ok {
+		wow64 := strings.TrimSpace(raw) == "?1"
+		if wow64 != v.ua.wow64 {
+			v.add(SeverityError, CodeWow64Mismatch, "Sec-CH-UA-Wow64",
+				"wow64 hint is %s but User-Agent WOW64 token is %t", raw, v.ua.wow64)
+		}
+	}
+}
+
+// uaInfo holds the facts Validate extracts from a User-Agent string.
+type uaInfo struct {
+	browser BrowserName
+	version Version
+	os      OSName
+	mobile  bool
+	webkit  bool // iOS browsers all run on WebKit regardless of branding
+	win64   bool
+	wow64   bool
+}
+
+var uaProductRe = regexp.MustCompile(`(Edg|EdgA|EdgiOS|HeadlessChrome|Chrome|CriOS|Firefox|FxiOS|Version)/([0-9.]+)`)
+
+func parseUA(ua string) uaInfo {
+	info := uaInfo{
+		mobile: strings.Contains(ua, " Mobile"),
+		win64:  strings.Contains(ua, "Win64"),
+		wow64:  strings.Contains(ua, "WOW64"),
+	}
+
+	switch {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		info.os = IOS
+		info.webkit = true
+	case strings.Contains(ua, "Windows"):
+		info.os = Windows
+	case strings.Contains(ua, "Android"):
+		info.os = Android
+	case strings.Contains(ua, "CrOS"):
+		info.os = ChromeOS
+	case strings.Contains(ua, "Macintosh"):
+		info.os = MacOS
+	case strings.Contains(ua, "Linux"):
+		info.os = Linux
+	}
+
+	products := make(map[string]string)
+	for _, m := range uaProductRe.FindAllStringSubmatch(ua, -1) {
+		products[m[1]] = m[2]
+	}
+	pick := func(browser BrowserName, tokens ...string) bool {
+		for _, t := range tokens {
+			if ver, ok := products[t]; ok {
+				info.browser = browser
+				info.version = parseVersionString(ver)
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case pick(Edge, "Edg", "EdgA", "EdgiOS"):
+	case pick(Firefox, "Firefox", "FxiOS"):
+	case pick(Chrome, "Chrome", "HeadlessChrome", "CriOS"):
+	case strings.Contains(ua, "Safari/") && pick(Safari, "Version"):
+	}
+
+	return info
+}
+
+// chromium reports whether the UA belongs to a Chromium-based browser that can send Client Hints.
+func (u uaInfo) chromium() bool {
+	return !u.webkit && (u.browser == Chrome || u.browser == Edge)
+}
+
+func (u uaInfo) major() int {
+	return majorOf(u.version.String())
+}
+
+// reduced reports whether the UA version has the MAJOR.0.0.0 shape of a reduced UA string.
+func (u uaInfo) reduced() bool {
+	if len(u.version.Components) == 0 {
+		return false
+	}
+	for _, c := range u.version.Components[1:] {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (u uaInfo) browserLabel() string {
+	if u.browser == "" {
+		return "this browser"
+	}
+	return string(u.browser)
+}
+
+func vendorBrand(b BrowserName) string {
+	switch b {
+	case Chrome:
+		return "Google Chrome"
+	case Edge:
+		return "Microsoft Edge"
+	default:
+		return ""
+	}
+}
+
+// isVendorBrand reports whether brand is the vendor brand of a known browser.
+func isVendorBrand(brand string) bool {
+	for _, b := range []BrowserName{Chrome, Edge} {
+		if vendorBrand(b) == brand {
+			return true
+		}
+	}
+	return false
+}
+
+// brandVersion is a single entry of a Sec-CH-UA style brand list.
+type brandVersion struct {
+	brand   string
+	version string
+}
+
+// parseBrandList parses a structured header list like `"Chromium";v="133", "Not(A:Brand";v="99"`.
+// Brand names may themselves contain separators, so quoted strings are scanned rather than split.
+func parseBrandList(s string) ([]brandVersion, error) {
+	var list []brandVersion
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		brand, tail, err := scanQuoted(rest)
+		if err != nil {
+			return nil, err
+		}
+		tail = strings.TrimLeft(tail, " ")
+		if !strings.HasPrefix(tail, ";v=") {
+			return nil, fmt.Errorf("missing version parameter for brand %q", brand)
+		}
+		version, tail, err := scanQuoted(tail[len(";v="):])
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, brandVersion{brand: brand, version: version})
+
+		tail = strings.TrimSpace(tail)
+		if tail != "" && !strings.HasPrefix(tail, ",") {
+			return nil, fmt.Errorf("unexpected %q after brand %q", tail, brand)
+		}
+		rest = strings.TrimSpace(strings.TrimPrefix(tail, ","))
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("empty brand list")
+	}
+	return list, nil
+}
+
+// scanQuoted reads a leading quoted string and returns its contents and the remainder.
+func scanQuoted(s string) (string, string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", "", fmt.Errorf("expected quoted string at %q", s)
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("malformed quoted string %q", s[:i+1])
+			}
+			return value, s[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated quoted string %q", s)
+}
+
+func majorOf(version string) int {
+	major, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	return major
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return s
+}
@@ -0,0 +1,186 @@
+package useragent
+
+import (
+	"testing"
+)
+
+const testChrome100UA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/100.0.4896.75 Safari/537.36"
+
+const testChromeUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36"
+
+func TestValidate(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	t.Run("GeneratedHeaders", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			res, err := g.Generate(WithAllClientHints())
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if findings := Validate(res.Headers); len(findings) != 0 {
+				t.Fatalf("Generated headers have findings: %v", findings)
+			}
+		}
+	})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		code    string
+	}{
+		{
+			name:    "MissingUserAgent",
+			headers: map[string]string{"Sec-CH-UA-Mobile": "?0"},
+			code:    CodeMissingUserAgent,
+		},
+		{
+			name: "MajorMismatch",
+			headers: map[string]string{
+				"User-Agent": testChromeUA,
+				"Sec-CH-UA":  `"Not(A:Brand";v="99", "Google Chrome";v="134", "Chromium";v="134"`,
+			},
+			code: CodeMajorMismatch,
+		},
+		{
+			name: "PlatformMismatch",
+			headers: map[string]string{
+				"User-Agent":         testChromeUA,
+				"sec-ch-ua-platform": `"macOS"`,
+			},
+			code: CodePlatformMismatch,
+		},
+		{
+			name: "MobileMismatch",
+			headers: map[string]string{
+				"User-Agent":       testChromeUA,
+				"Sec-CH-UA-Mobile": "?1",
+			},
+			code: CodeMobileMismatch,
+		},
+		{
+			name: "FirefoxSendsHints",
+			headers: map[string]string{
+				"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:135.0) Gecko/20100101 Firefox/135.0",
+				"Sec-CH-UA":  `"Not(A:Brand";v="99", "Chromium";v="135"`,
+			},
+			code: CodeHintUnsupported,
+		},
+		{
+			name: "UnknownBrand",
+			headers: map[string]string{
+				"User-Agent": testChromeUA,
+				"Sec-CH-UA":  `"Not(A:Brand";v="99", "Microsoft Edge";v="133", "Chromium";v="133"`,
+			},
+			code: CodeUnknownBrand,
+		},
+		{
+			name: "TwoGreaseBrands",
+			headers: map[string]string{
+				"User-Agent": testChromeUA,
+				"Sec-CH-UA":  `"Not(A:Brand";v="99", "Not?A_Brand";v="99", "Google Chrome";v="133", "Chromium";v="133"`,
+			},
+			code: CodeGreaseCount,
+		},
+		{
+			name: "VendorBrandOfOtherBrowser",
+			headers: map[string]string{
+				"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36 Edg/133.0.0.0",
+				"Sec-CH-UA":  `"Not(A:Brand";v="99", "Google Chrome";v="133", "Chromium";v="133"`,
+			},
+			code: CodeUnknownBrand,
+		},
+		{
+			name: "UnreducedFullVersionDiffers",
+			headers: map[string]string{
+				"User-Agent":                  "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.6943.53 Safari/537.36",
+				"Sec-CH-UA-Full-Version-List": `"Not(A:Brand";v="99.0.0.0", "Google Chrome";v="133.0.6943.98", "Chromium";v="133.0.6943.98"`,
+			},
+			code: CodeFullVersionDiffers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := Validate(tt.headers)
+			for _, f := range findings {
+				if f.Code == tt.code && f.Severity == SeverityError {
+					return
+				}
+			}
+			t.Errorf("Expected %s error, got %v", tt.code, findings)
+		})
+	}
+
+	warnings := []struct {
+		name    string
+		headers map[string]string
+		code    string
+	}{
+		{
+			name: "ModernGreaseOnOldChrome",
+			headers: map[string]string{
+				"User-Agent": testChrome100UA,
+				"Sec-CH-UA":  `"Not(A:Brand";v="99", "Chromium";v="100", "Google Chrome";v="100"`,
+			},
+			code: CodeGreaseFormat,
+		},
+		{
+			name: "LegacyGreaseOnNewChrome",
+			headers: map[string]string{
+				"User-Agent": testChromeUA,
+				"Sec-CH-UA":  `" Not A;Brand";v="99", "Google Chrome";v="133", "Chromium";v="133"`,
+			},
+			code: CodeGreaseFormat,
+		},
+		{
+			name: "ChromiumFork",
+			headers: map[string]string{
+				"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 OPR/106.0.0.0",
+				"Sec-CH-UA":  `"Opera";v="106", "Not)A;Brand";v="99", "Chromium";v="120"`,
+			},
+			code: CodeUnknownBrand,
+		},
+	}
+
+	for _, tt := range warnings {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := Validate(tt.headers)
+			if HasErrors(findings) {
+				t.Fatalf("Expected no errors, got %v", findings)
+			}
+			for _, f := range findings {
+				if f.Code == tt.code && f.Severity == SeverityWarning {
+					return
+				}
+			}
+			t.Errorf("Expected %s warning, got %v", tt.code, findings)
+		})
+	}
+
+	t.Run("LegacyGrease", func(t *testing.T) {
+		findings := Validate(map[string]string{
+			"User-Agent":       testChrome100UA,
+			"Sec-CH-UA":        `" Not A;Brand";v="99", "Chromium";v="100", "Google Chrome";v="100"`,
+			"Sec-CH-UA-Mobile": "?0",
+		})
+		if len(findings) != 0 {
+			t.Errorf("Expected no findings, got %v", findings)
+		}
+	})
+
+	t.Run("ConsistentCapturedHeaders", func(t *testing.T) {
+		findings := Validate(map[string]string{
+			"user-agent":                  testChromeUA,
+			"sec-ch-ua":                   `"Not(A:Brand";v="99", "Google Chrome";v="133", "Chromium";v="133"`,
+			"sec-ch-ua-full-version-list": `"Not(A:Brand";v="99.0.0.0", "Google Chrome";v="133.0.6943.98", "Chromium";v="133.0.6943.98"`,
+			"sec-ch-ua-mobile":            "?0",
+			"sec-ch-ua-platform":          `"Windows"`,
+		})
+		if len(findings) != 0 {
+			t.Errorf("Expected no findings, got %v", findings)
+		}
+	})
+}
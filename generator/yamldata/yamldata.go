@@ -0,0 +1,147 @@
+// Package yamldata enables loading data files written as YAML. The
+// generator package decodes JSON on its own but keeps the YAML parser out of
+// binaries that only use the embedded data; import this package for its
+// side effect to load YAML with File, Reader or NewWithSource:
+//
+//	import _ "github.com/r1x0s/go-useragent-utils/generator/yamldata"
+//
+// It also lets ValidateData report the line and column of every problem,
+// including values that do not fit their field, which are skipped so the
+// rest of the data is still checked.
+package yamldata
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	useragent "github.com/r1x0s/go-useragent-utils/generator"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	useragent.RegisterYAMLDecoder(decode)
+	useragent.RegisterYAMLLocator(Locate)
+}
+
+// decode decodes YAML into v through a yaml.Node. Values that do not fit
+// their field, such as a version key that is not an integer, are skipped
+// and returned as useragent.DataErrors with their path and position.
+func decode(data []byte, v interface{}) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	err := doc.Decode(v)
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	problems := make(useragent.DataErrors, len(typeErr.Errors))
+	for i, msg := range typeErr.Errors {
+		problems[i] = typeProblem(&doc, msg)
+	}
+	return problems
+}
+
+// typeProblem turns a message of a yaml.TypeError, such as
+// "line 5: cannot unmarshal !!str `abc` into int", into a DataError at the
+// node it names.
+func typeProblem(doc *yaml.Node, msg string) useragent.DataError {
+	var line int
+	if _, err := fmt.Sscanf(msg, "line %d:", &line); err != nil {
+		return useragent.DataError{Msg: msg}
+	}
+	problem := useragent.DataError{Line: line, Msg: msg[strings.Index(msg, ":")+2:]}
+
+	// The value is quoted with backticks unless it is too long.
+	value := ""
+	if start := strings.Index(msg, "`"); start >= 0 {
+		if end := strings.Index(msg[start+1:], "`"); end >= 0 {
+			value = msg[start+1 : start+1+end]
+		}
+	}
+	if path, node := find(doc.Content[0], nil, line, value); node != nil {
+		problem.Path, problem.Column = path, node.Column
+	}
+	return problem
+}
+
+// find returns the first scalar on line with the given value, or any value
+// when it is empty, and the path leading to it.
+func find(node *yaml.Node, path []string, line int, value string) ([]string, *yaml.Node) {
+	matches := func(n *yaml.Node) bool {
+		return n.Kind == yaml.ScalarNode && n.Line == line && (value == "" || n.Value == value)
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+			keyPath := append(path[:len(path):len(path)], key.Value)
+			if matches(key) {
+				return keyPath, key
+			}
+			if p, n := find(val, keyPath, line, value); n != nil {
+				return p, n
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if p, n := find(item, append(path[:len(path):len(path)], strconv.Itoa(i)), line, value); n != nil {
+				return p, n
+			}
+		}
+	case yaml.ScalarNode:
+		if matches(node) {
+			return path, node
+		}
+	}
+	return nil, nil
+}
+
+// Locate returns the line and column of path in YAML or JSON data. Mapping
+// keys are matched by their text and sequence items by index. When the path
+// does not exist in full, the position of its longest existing prefix is
+// returned; 0, 0 means the data could not be parsed.
+func Locate(data []byte, path []string) (line, column int) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return 0, 0
+	}
+	node := doc.Content[0]
+	line, column = node.Line, node.Column
+	for _, key := range path {
+		next, at := child(node, key)
+		if next == nil {
+			break
+		}
+		node = next
+		line, column = at.Line, at.Column
+	}
+	return line, column
+}
+
+// child returns the node under key and the node whose position identifies
+// it: the key of a mapping entry or the item of a sequence.
+func child(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], node.Content[i]
+			}
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i], node.Content[i]
+		}
+	}
+	return nil, nil
+}
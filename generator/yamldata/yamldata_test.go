@@ -0,0 +1,89 @@
+package yamldata
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	useragent "github.com/r1x0s/go-useragent-utils/generator"
+)
+
+const data = `browsers:
+    chrome:
+        windows:
+            ua_template: "Chrome/{{version}}"
+            versions:
+                150:
+                    0:
+                        7500: [12, 1.5]
+`
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		path         []string
+		line, column int
+	}{
+		{[]string{"browsers", "chrome", "windows", "ua_template"}, 4, 13},
+		{[]string{"browsers", "chrome", "windows", "versions", "150", "0", "7500", "1"}, 8, 36},
+		{[]string{"browsers", "chrome", "linux"}, 2, 5}, // Longest existing prefix
+	}
+	for _, tt := range tests {
+		line, column := Locate([]byte(data), tt.path)
+		if line != tt.line || column != tt.column {
+			t.Errorf("Locate(%v) = %d:%d, want %d:%d", tt.path, line, column, tt.line, tt.column)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	const bad = `browsers:
+    chrome:
+        windows:
+            ua_template: "Chrome"
+            variants:
+                - weight: heavy
+            versions:
+                150:
+                    0:
+                        7500: [12]
+                beta: ~
+`
+	config, err := useragent.Reader(strings.NewReader(bad)).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	err = useragent.ValidateData(config)
+	var problems useragent.DataErrors
+	if !errors.As(err, &problems) || len(problems) != 3 {
+		t.Fatalf("Expected three problems, got %v", err)
+	}
+	want := []struct {
+		path         string
+		line, column int
+	}{
+		{"browsers.chrome.windows.variants.0.weight", 6, 27},
+		{"browsers.chrome.windows.versions.beta", 11, 17},
+		{"browsers.chrome.windows.ua_template", 4, 13}, // Still validated
+	}
+	for i, w := range want {
+		p := problems[i]
+		if path := strings.Join(p.Path, "."); path != w.path || p.Line != w.line || p.Column != w.column {
+			t.Errorf("Problem %d = %v, want %s at %d:%d", i, p, w.path, w.line, w.column)
+		}
+	}
+
+	if _, err := useragent.NewWithSource(useragent.Reader(strings.NewReader(bad))); !errors.As(err, &problems) {
+		t.Errorf("Expected loading to fail with DataErrors, got %v", err)
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	_, err := useragent.NewWithSource(useragent.Reader(strings.NewReader(data)), useragent.WithStrictData())
+	var problems useragent.DataErrors
+	if !errors.As(err, &problems) || len(problems) != 1 {
+		t.Fatalf("Expected one problem, got %v", err)
+	}
+	if p := problems[0]; p.Line != 8 || p.Column != 36 {
+		t.Errorf("Expected problem at 8:36, got %d:%d", p.Line, p.Column)
+	}
+}